@@ -0,0 +1,18 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/SIKU-KR/HolyBean/_legacy/aws-go/handler"
+	"github.com/SIKU-KR/HolyBean/_legacy/aws-go/holybean"
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+func main() {
+	client, err := holybean.NewDynamoDBClient(context.Background())
+	if err != nil {
+		log.Fatalf("unable to load SDK config, %v", err)
+	}
+	lambda.Start(handler.AmendOrder(holybean.NewDynamoOrderRepository(client)))
+}
@@ -1,5 +0,0 @@
-module auth_api_key
-
-    go 1.23.2
-
-    require github.com/aws/aws-lambda-go v1.49.0 // indirect
@@ -0,0 +1,307 @@
+// holybean-rebuild는 holybean 주문 테이블을 읽어 파생 데이터를 다시 계산하고, 어긋난 것을 고칩니다.
+// firebase/src/rebuild.ts의 rebuildDerived와 같은 규칙을 따릅니다.
+//
+//   - 일별 매출 집계(holybean-meta, pk=rollup): 정산 완료 주문과 외상 입금(Order.Rollups)으로 다시 계산해 통째로 바꿉니다.
+//   - 주문 번호 카운터(holybean-meta, pk=orderCounter): 그날 가장 큰 주문 번호보다 작으면 올립니다. 내리지는 않습니다.
+//   - 외상 색인(creditStatus-index): DynamoDB가 관리하는 GSI라 고치지 않고 어긋난 주문만 알려 줍니다.
+//
+// 사용:
+//
+//	go run ./cmd/holybean-rebuild --dry-run                             # 전체 기간, 차이만 출력
+//	go run ./cmd/holybean-rebuild --from 2026-10-01 --to 2026-10-31     # 기간만 다시 계산해 쓰기
+//
+// 집계를 덮어쓰는 동안 들어온 주문은 반영되지 않을 수 있으므로 영업시간 밖에 실행하세요.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/SIKU-KR/HolyBean/_legacy/aws-go/holybean"
+)
+
+// 기간을 주지 않았을 때 쓰는 양 끝 날짜입니다. YYYY-MM-DD 문자열 비교로 모든 날짜를 포함합니다.
+const (
+	firstDate = "0000-01-01"
+	lastDate  = "9999-12-31"
+)
+
+// fix는 고칠 것 하나입니다. apply가 nil이면 알리기만 합니다.
+type fix struct {
+	lines []string
+	apply func(ctx context.Context) error
+}
+
+func main() {
+	from := flag.String("from", "", "first orderDate to rebuild, YYYY-MM-DD (default: all)")
+	to := flag.String("to", "", "last orderDate to rebuild, YYYY-MM-DD (default: all)")
+	dryRun := flag.Bool("dry-run", false, "print the differences without writing anything")
+	flag.Parse()
+
+	start, end := firstDate, lastDate
+	if *from != "" {
+		start = mustParseDate(*from)
+	}
+	if *to != "" {
+		end = mustParseDate(*to)
+	}
+	if start > end {
+		log.Fatalf("--from(%s)은 --to(%s)보다 늦을 수 없습니다", start, end)
+	}
+
+	ctx := context.Background()
+	client, err := holybean.NewDynamoDBClient(ctx)
+	if err != nil {
+		log.Fatalf("unable to load SDK config, %v", err)
+	}
+	repo := holybean.NewDynamoOrderRepository(client)
+
+	derived, count, err := collect(ctx, repo, start, end)
+	if err != nil {
+		log.Fatalf("주문 읽기 실패: %v", err)
+	}
+	log.Printf("주문 %d건을 읽었습니다 (%s ~ %s)", count, start, end)
+
+	var fixes []fix
+	rollupFixes, err := planRollups(ctx, repo, derived, start, end)
+	if err != nil {
+		log.Fatalf("집계 읽기 실패: %v", err)
+	}
+	fixes = append(fixes, rollupFixes...)
+	counterFixes, err := planCounters(ctx, repo, derived, start, end)
+	if err != nil {
+		log.Fatalf("카운터 읽기 실패: %v", err)
+	}
+	fixes = append(fixes, counterFixes...)
+	creditFixes, err := checkOpenCredits(ctx, repo, derived, start, end)
+	if err != nil {
+		log.Fatalf("외상 색인 읽기 실패: %v", err)
+	}
+	fixes = append(fixes, creditFixes...)
+
+	for _, f := range fixes {
+		for _, line := range f.lines {
+			fmt.Println(line)
+		}
+	}
+	fmt.Printf("집계 %d건, 카운터 %d건을 고칩니다. 외상 색인 불일치 %d건.\n", len(rollupFixes), len(counterFixes), len(creditFixes))
+	if *dryRun {
+		fmt.Println("--dry-run: 아무것도 쓰지 않았습니다.")
+		return
+	}
+
+	for _, f := range fixes {
+		if f.apply == nil {
+			continue
+		}
+		if err := f.apply(ctx); err != nil {
+			log.Fatalf("%s: %v", f.lines[0], err)
+		}
+	}
+	fmt.Println("완료했습니다.")
+}
+
+func mustParseDate(value string) string {
+	if _, err := time.Parse(holybean.DateLayout, value); err != nil {
+		log.Fatalf("날짜 형식은 YYYY-MM-DD 여야 합니다: %s", value)
+	}
+	return value
+}
+
+// collect는 [start, end] 기간의 파생 데이터를 계산합니다.
+// 외상 입금은 주문 날짜가 아니라 받은 날 집계에 잡히므로, 기간 밖 주문이라도 기간 안에 받은 입금이 있으면 함께 읽습니다.
+// 그래서 기간을 주더라도 테이블 전체를 Scan하고, 기간 밖 날짜의 결과는 버립니다.
+func collect(ctx context.Context, repo holybean.RebuildRepository, start, end string) (*holybean.Derived, int, error) {
+	inRange := func(date string) bool { return date >= start && date <= end }
+	derived := holybean.NewDerived()
+	count := 0
+	err := repo.ForEach(ctx, func(order holybean.Order) error {
+		relevant := inRange(order.OrderDate)
+		for _, payment := range order.CreditPayments {
+			relevant = relevant || inRange(payment.Date)
+		}
+		if relevant {
+			derived.Add(order)
+			count++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for date := range derived.Rollups {
+		if !inRange(date) {
+			delete(derived.Rollups, date)
+		}
+	}
+	for date := range derived.LastOrderNums {
+		if !inRange(date) {
+			delete(derived.LastOrderNums, date)
+		}
+	}
+	for id := range derived.OpenCredits {
+		if !inRange(id.OrderDate) {
+			delete(derived.OpenCredits, id)
+		}
+	}
+	return derived, count, nil
+}
+
+func planRollups(ctx context.Context, repo holybean.RebuildRepository, derived *holybean.Derived, start, end string) ([]fix, error) {
+	stored, err := repo.ListRollups(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	current := make(map[string]holybean.Rollup, len(stored))
+	for _, rollup := range stored {
+		current[rollup.Date] = rollup.Compact()
+	}
+
+	dates := make(map[string]bool)
+	for date := range current {
+		dates[date] = true
+	}
+	for date := range derived.Rollups {
+		dates[date] = true
+	}
+
+	var fixes []fix
+	for _, date := range sortedKeys(dates) {
+		have, stored := current[date]
+		want := derived.Rollups[date].Compact()
+		switch {
+		case want.IsZero() && stored:
+			fixes = append(fixes, fix{
+				lines: []string{fmt.Sprintf("집계 %s: 정산 완료 주문이 없으므로 삭제 (합계 %d)", date, have.Total)},
+				apply: func(ctx context.Context) error { return repo.DeleteRollup(ctx, date) },
+			})
+		case want.IsZero():
+			// 주문도 집계도 없음
+		case !stored:
+			fixes = append(fixes, fix{
+				lines: []string{fmt.Sprintf("집계 %s: 없음 → 합계 %d", date, want.Total)},
+				apply: func(ctx context.Context) error { return repo.PutRollup(ctx, want) },
+			})
+		default:
+			if lines := rollupDiff(have, want); len(lines) > 0 {
+				fixes = append(fixes, fix{
+					lines: append([]string{fmt.Sprintf("집계 %s: 합계 %d → %d", date, have.Total, want.Total)}, lines...),
+					apply: func(ctx context.Context) error { return repo.PutRollup(ctx, want) },
+				})
+			}
+		}
+	}
+	return fixes, nil
+}
+
+// rollupDiff는 have와 want의 메뉴·결제 수단별 차이를 한 줄씩 반환합니다. 같으면 빈 목록입니다.
+func rollupDiff(have, want holybean.Rollup) []string {
+	var lines []string
+	menus := make(map[string]bool)
+	for name := range have.MenuSales {
+		menus[name] = true
+	}
+	for name := range want.MenuSales {
+		menus[name] = true
+	}
+	for _, name := range sortedKeys(menus) {
+		if a, b := have.MenuSales[name], want.MenuSales[name]; a != b {
+			lines = append(lines, fmt.Sprintf("  메뉴 %s: %d개/%d원 → %d개/%d원", name, a.Quantity, a.Sales, b.Quantity, b.Sales))
+		}
+	}
+	methods := make(map[string]bool)
+	for method := range have.PaymentSales {
+		methods[method] = true
+	}
+	for method := range want.PaymentSales {
+		methods[method] = true
+	}
+	for _, method := range sortedKeys(methods) {
+		if a, b := have.PaymentSales[method], want.PaymentSales[method]; a != b {
+			lines = append(lines, fmt.Sprintf("  결제 %s: %d → %d", method, a, b))
+		}
+	}
+	if len(lines) == 0 && have.Total != want.Total {
+		lines = append(lines, fmt.Sprintf("  합계만 다름: %d → %d", have.Total, want.Total))
+	}
+	return lines
+}
+
+func planCounters(ctx context.Context, repo holybean.RebuildRepository, derived *holybean.Derived, start, end string) ([]fix, error) {
+	counters, err := repo.OrderCounters(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	dates := make(map[string]bool)
+	for date := range derived.LastOrderNums {
+		dates[date] = true
+	}
+
+	var fixes []fix
+	for _, date := range sortedKeys(dates) {
+		want := derived.LastOrderNums[date]
+		if have, ok := counters[date]; !ok || have < want {
+			fixes = append(fixes, fix{
+				lines: []string{fmt.Sprintf("카운터 %s: %d → %d", date, have, want)},
+				apply: func(ctx context.Context) error { return repo.RaiseOrderCounter(ctx, date, want) },
+			})
+		}
+	}
+	return fixes, nil
+}
+
+// checkOpenCredits는 미정산 주문과 creditStatus-index 조회 결과를 대조합니다.
+// GSI는 주문에서 DynamoDB가 만드는 것이므로 고칠 것이 아니라 알릴 것만 반환합니다.
+func checkOpenCredits(ctx context.Context, repo holybean.RebuildRepository, derived *holybean.Derived, start, end string) ([]fix, error) {
+	indexed, err := repo.ListOpenCredits(ctx)
+	if err != nil {
+		return nil, err
+	}
+	inIndex := make(map[holybean.OrderID]holybean.Order)
+	for _, order := range indexed {
+		if order.OrderDate >= start && order.OrderDate <= end {
+			inIndex[holybean.OrderID{OrderDate: order.OrderDate, OrderNum: order.OrderNum}] = order
+		}
+	}
+
+	var fixes []fix
+	for _, id := range sortedOrderIDs(derived.OpenCredits) {
+		if _, ok := inIndex[id]; !ok {
+			fixes = append(fixes, fix{lines: []string{fmt.Sprintf("외상 색인 %s #%d: 미정산 주문이지만 %s에 없습니다", id.OrderDate, id.OrderNum, holybean.CreditStatusIndex)}})
+		}
+	}
+	for _, id := range sortedOrderIDs(inIndex) {
+		if _, ok := derived.OpenCredits[id]; !ok {
+			fixes = append(fixes, fix{lines: []string{fmt.Sprintf("외상 색인 %s #%d: %s에 있지만 미정산 주문이 아닙니다", id.OrderDate, id.OrderNum, holybean.CreditStatusIndex)}})
+		}
+	}
+	return fixes, nil
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedOrderIDs(orders map[holybean.OrderID]holybean.Order) []holybean.OrderID {
+	ids := make([]holybean.OrderID, 0, len(orders))
+	for id := range orders {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if ids[i].OrderDate != ids[j].OrderDate {
+			return ids[i].OrderDate < ids[j].OrderDate
+		}
+		return ids[i].OrderNum < ids[j].OrderNum
+	})
+	return ids
+}
@@ -0,0 +1,314 @@
+// holybean-redate는 orderDate가 영업일 규칙(holybean.Calendar)과 맞지 않게 저장된 주문을 찾아 옮깁니다.
+// Lambda 시계(UTC)로 날짜를 정하던 때에는 Asia/Seoul 오전 9시 전 주문이 전날로 저장되었습니다.
+//
+// 주문을 받은 시각(createdAt)이 있는 주문만 판단할 수 있습니다. createdAt이 없는 예전 주문은 서버가 UTC 날짜마다
+// 번호를 이어 붙였으므로 번호 순서로도 자정(KST) 경계를 알 수 없습니다. 이런 주문은 목록을 출력하고 실패(종료 코드 1)합니다.
+// 운영자가 영수증 등으로 날짜를 확인한 주문은 --moves 파일에 적어 같은 방법으로 옮기고,
+// 옮길 필요가 없다고 확인한 나머지가 있을 때만 --allow-untimed로 성공 처리합니다.
+// 옮긴 주문은 새 날짜의 주문 번호 카운터에서 번호를 새로 받습니다. 두 날짜의 집계와 멱등성 키 기록도 함께 옮깁니다.
+//
+// --moves 파일은 한 줄에 하나씩 "orderDate#orderNum 새날짜"를 적습니다. 빈 줄과 #으로 시작하는 줄은 건너뜁니다.
+// 판단 못 한 주문 목록이 이 형식의 키(예: 2026-03-02#7)로 출력되므로 그 뒤에 날짜를 붙이면 됩니다.
+//
+//	2026-03-02#7 2026-03-01
+//	2026-03-02#8 -> 2026-03-01
+//
+// 사용:
+//
+//	go run ./cmd/holybean-redate                                      # 전체 기간, 옮길 주문만 출력
+//	go run ./cmd/holybean-redate --from 2026-10-01 --to 2026-10-31 --commit
+//	go run ./cmd/holybean-redate --moves untimed.txt                    # 직접 정한 날짜까지 출력
+//	go run ./cmd/holybean-redate --moves untimed.txt --commit --allow-untimed
+//
+// 영업일 규칙은 핸들러와 같은 HOLYBEAN_TIME_ZONE, HOLYBEAN_DAY_ROLLOVER_HOUR 환경 변수로 정합니다.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SIKU-KR/HolyBean/_legacy/aws-go/holybean"
+)
+
+// 기간을 주지 않았을 때 쓰는 양 끝 날짜입니다. YYYY-MM-DD 문자열 비교로 모든 날짜를 포함합니다.
+const (
+	firstDate = "0000-01-01"
+	lastDate  = "9999-12-31"
+)
+
+// move는 옮길 주문 하나입니다.
+type move struct {
+	order  holybean.Order
+	date   string // 영업일 규칙이나 --moves 파일로 정한 orderDate
+	manual bool   // --moves 파일에서 온 것
+}
+
+func main() {
+	from := flag.String("from", "", "first orderDate to check, YYYY-MM-DD (default: all)")
+	to := flag.String("to", "", "last orderDate to check, YYYY-MM-DD (default: all)")
+	commit := flag.Bool("commit", false, "move the misfiled orders (default: only print them)")
+	allowUntimed := flag.Bool("allow-untimed", false, "exit successfully even if some orders have no createdAt and could not be checked")
+	movesFile := flag.String("moves", "", "file of \"orderDate#orderNum newDate\" lines re-dating orders that have no createdAt")
+	flag.Parse()
+
+	start, end := firstDate, lastDate
+	if *from != "" {
+		start = mustParseDate(*from)
+	}
+	if *to != "" {
+		end = mustParseDate(*to)
+	}
+	if start > end {
+		log.Fatalf("--from(%s)은 --to(%s)보다 늦을 수 없습니다", start, end)
+	}
+	calendar, err := holybean.LoadBusinessCalendar()
+	if err != nil {
+		log.Fatalf("영업일 설정 오류: %v", err)
+	}
+
+	ctx := context.Background()
+	client, err := holybean.NewDynamoDBClient(ctx)
+	if err != nil {
+		log.Fatalf("unable to load SDK config, %v", err)
+	}
+	repo := holybean.NewDynamoOrderRepository(client)
+
+	var manual []manualMove
+	if *movesFile != "" {
+		if manual, err = readManualMoves(*movesFile); err != nil {
+			log.Fatalf("--moves 파일 오류: %v", err)
+		}
+	}
+
+	moves, untimed, err := findMisfiled(ctx, repo, calendar, *from != "" && *to != "", start, end)
+	if err != nil {
+		log.Fatalf("주문 읽기 실패: %v", err)
+	}
+	manualMoves, err := resolveManualMoves(ctx, repo, manual)
+	if err != nil {
+		log.Fatalf("--moves 파일 오류: %v", err)
+	}
+	moves = append(moves, manualMoves...)
+	untimed = withoutMoved(untimed, manualMoves)
+	for _, m := range moves {
+		if m.manual {
+			fmt.Printf("주문 %s #%d: --moves 파일 → %s\n", m.order.OrderDate, m.order.OrderNum, m.date)
+			continue
+		}
+		fmt.Printf("주문 %s #%d: createdAt %s → 영업일 %s\n", m.order.OrderDate, m.order.OrderNum, m.order.CreatedAt, m.date)
+	}
+	for _, order := range untimed {
+		fmt.Printf("판단 못 함: %s (createdAt 없음, 총액 %d)\n", orderKey(order.OrderDate, order.OrderNum), order.TotalAmount)
+	}
+	fmt.Printf("옮길 주문 %d건. createdAt이 없어 판단하지 못한 주문 %d건.\n", len(moves), len(untimed))
+	if !*commit {
+		fmt.Println("--commit 없이 실행했으므로 아무것도 쓰지 않았습니다.")
+		failOnUntimed(len(untimed), *allowUntimed)
+		return
+	}
+
+	moved := 0
+	for _, m := range moves {
+		order, err := redate(ctx, repo, m)
+		if errors.Is(err, holybean.ErrOrderChanged) {
+			log.Printf("주문 %s #%d: 읽은 뒤 바뀌어 건너뜁니다. 다시 실행하세요.", m.order.OrderDate, m.order.OrderNum)
+			continue
+		}
+		if err != nil {
+			log.Fatalf("주문 %s #%d 옮기기 실패: %v", m.order.OrderDate, m.order.OrderNum, err)
+		}
+		fmt.Printf("주문 %s #%d → %s #%d\n", m.order.OrderDate, m.order.OrderNum, order.OrderDate, order.OrderNum)
+		moved++
+	}
+	fmt.Printf("%d건을 옮겼습니다.\n", moved)
+	failOnUntimed(len(untimed), *allowUntimed)
+}
+
+// failOnUntimed는 판단하지 못한 주문이 있으면 성공으로 끝내지 않습니다.
+// 예전 주문 중 잘못 저장된 것이 바로 이 목록에 있을 수 있으므로, 확인했다는 --allow-untimed 없이는 종료 코드 1입니다.
+func failOnUntimed(untimed int, allowed bool) {
+	if untimed > 0 && !allowed {
+		log.Fatalf("createdAt이 없는 주문 %d건은 날짜가 맞는지 판단하지 못했습니다. 옮길 주문은 --moves 파일에 적고, "+
+			"나머지가 맞는 날짜임을 확인했으면 --allow-untimed로 다시 실행하세요.", untimed)
+	}
+}
+
+// manualMove는 --moves 파일의 한 줄입니다.
+type manualMove struct {
+	id   holybean.OrderID
+	date string
+	line int
+}
+
+// orderKey는 --moves 파일과 출력에 쓰는 "orderDate#orderNum" 키입니다.
+func orderKey(orderDate string, orderNum int) string {
+	return orderDate + "#" + strconv.Itoa(orderNum)
+}
+
+// readManualMoves는 --moves 파일을 읽습니다. 한 줄이라도 형식이 틀리면 아무것도 옮기지 않도록 오류를 반환합니다.
+func readManualMoves(path string) ([]manualMove, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var entries []manualMove
+	seen := make(map[holybean.OrderID]int)
+	for i, line := range strings.Split(string(data), "\n") {
+		n := i + 1
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 3 && (fields[1] == "->" || fields[1] == "→") {
+			fields = []string{fields[0], fields[2]}
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%d번째 줄: \"orderDate#orderNum 새날짜\" 형식이어야 합니다: %s", n, line)
+		}
+		orderDate, num, ok := strings.Cut(fields[0], "#")
+		orderNum, err := strconv.Atoi(num)
+		if _, derr := time.Parse(holybean.DateLayout, orderDate); !ok || err != nil || derr != nil || orderNum <= 0 {
+			return nil, fmt.Errorf("%d번째 줄: 주문 키는 orderDate#orderNum 형식이어야 합니다: %s", n, fields[0])
+		}
+		if _, err := time.Parse(holybean.DateLayout, fields[1]); err != nil {
+			return nil, fmt.Errorf("%d번째 줄: 날짜 형식은 YYYY-MM-DD 여야 합니다: %s", n, fields[1])
+		}
+		id := holybean.OrderID{OrderDate: orderDate, OrderNum: orderNum}
+		if prev, dup := seen[id]; dup {
+			return nil, fmt.Errorf("%d번째 줄: %s는 %d번째 줄에 이미 있습니다", n, fields[0], prev)
+		}
+		seen[id] = n
+		entries = append(entries, manualMove{id: id, date: fields[1], line: n})
+	}
+	return entries, nil
+}
+
+// resolveManualMoves는 --moves 파일의 주문을 읽어 옮길 목록으로 만듭니다.
+// createdAt이 있는 주문은 영업일 규칙으로 판단하므로 받지 않고, 이미 그 날짜인 주문도 오류로 봅니다.
+func resolveManualMoves(ctx context.Context, repo holybean.RebuildRepository, entries []manualMove) ([]move, error) {
+	var moves []move
+	for _, entry := range entries {
+		key := orderKey(entry.id.OrderDate, entry.id.OrderNum)
+		order, err := repo.Get(ctx, entry.id.OrderDate, entry.id.OrderNum)
+		if errors.Is(err, holybean.ErrOrderNotFound) {
+			return nil, fmt.Errorf("%d번째 줄: 주문 %s가 없습니다", entry.line, key)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if order.CreatedAt != "" {
+			return nil, fmt.Errorf("%d번째 줄: 주문 %s는 createdAt이 있어 영업일 규칙으로 옮깁니다", entry.line, key)
+		}
+		if entry.date == order.OrderDate {
+			return nil, fmt.Errorf("%d번째 줄: 주문 %s는 이미 %s입니다", entry.line, key, entry.date)
+		}
+		moves = append(moves, move{order: order, date: entry.date, manual: true})
+	}
+	return moves, nil
+}
+
+// withoutMoved는 untimed에서 moves로 옮길 주문을 뺍니다.
+func withoutMoved(untimed []holybean.Order, moves []move) []holybean.Order {
+	moving := make(map[holybean.OrderID]bool, len(moves))
+	for _, m := range moves {
+		moving[holybean.OrderID{OrderDate: m.order.OrderDate, OrderNum: m.order.OrderNum}] = true
+	}
+	var rest []holybean.Order
+	for _, order := range untimed {
+		if !moving[holybean.OrderID{OrderDate: order.OrderDate, OrderNum: order.OrderNum}] {
+			rest = append(rest, order)
+		}
+	}
+	return rest
+}
+
+func mustParseDate(value string) string {
+	if _, err := time.Parse(holybean.DateLayout, value); err != nil {
+		log.Fatalf("날짜 형식은 YYYY-MM-DD 여야 합니다: %s", value)
+	}
+	return value
+}
+
+// findMisfiled는 [start, end] 기간에서 createdAt의 영업일과 orderDate가 다른 주문과, createdAt이 없어 판단하지 못한 주문을 찾습니다.
+// 기간이 양쪽 다 정해졌고 holybean.MaxRangeDays일 이내이면 날짜별로 Query하고, 아니면 테이블 전체를 Scan합니다.
+func findMisfiled(ctx context.Context, repo holybean.RebuildRepository, calendar holybean.BusinessCalendar, bounded bool, start, end string) ([]move, []holybean.Order, error) {
+	var moves []move
+	var untimed []holybean.Order
+	check := func(order holybean.Order) error {
+		if order.CreatedAt == "" {
+			untimed = append(untimed, order)
+			return nil
+		}
+		createdAt, err := holybean.ParseCreatedAt(order.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("주문 %s #%d의 createdAt을 읽지 못했습니다: %w", order.OrderDate, order.OrderNum, err)
+		}
+		if date := calendar.Date(createdAt); date != order.OrderDate {
+			moves = append(moves, move{order: order, date: date})
+		}
+		return nil
+	}
+
+	if bounded {
+		orders, err := repo.ListRange(ctx, start, end)
+		if errors.Is(err, holybean.ErrRangeTooLong) {
+			bounded = false
+		} else if err != nil {
+			return nil, nil, err
+		}
+		for _, order := range orders {
+			if err := check(order); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	if !bounded {
+		err := repo.ForEach(ctx, func(order holybean.Order) error {
+			if order.OrderDate < start || order.OrderDate > end {
+				return nil
+			}
+			return check(order)
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	sort.Slice(moves, func(i, j int) bool { return orderBefore(moves[i].order, moves[j].order) })
+	sort.Slice(untimed, func(i, j int) bool { return orderBefore(untimed[i], untimed[j]) })
+	return moves, untimed, nil
+}
+
+// orderBefore는 (orderDate, orderNum) 순서입니다.
+func orderBefore(a, b holybean.Order) bool {
+	if a.OrderDate != b.OrderDate {
+		return a.OrderDate < b.OrderDate
+	}
+	return a.OrderNum < b.OrderNum
+}
+
+// redate는 새 날짜의 카운터에서 번호를 받아 주문을 옮깁니다.
+// 그 번호를 그 사이 다른 주문이 썼으면 번호를 다시 받습니다.
+func redate(ctx context.Context, repo holybean.RebuildRepository, m move) (holybean.Order, error) {
+	const maxAttempts = 3
+	for attempt := 1; ; attempt++ {
+		orderNum, err := holybean.AllocateOrderNum(ctx, repo, m.date)
+		if err != nil {
+			return holybean.Order{}, err
+		}
+		order, err := repo.MoveOrder(ctx, m.order, m.date, orderNum)
+		var conflict *holybean.OrderConflictError
+		if !errors.As(err, &conflict) || attempt == maxAttempts {
+			return order, err
+		}
+	}
+}
@@ -0,0 +1,281 @@
+// holybean-server는 모든 Lambda 핸들러를 하나의 net/http 서버에 올려 로컬에서 실행합니다.
+// API Gateway와 같은 경로·경로 파라미터를 쓰므로 앱과 대시보드의 API 주소만 바꾸면 됩니다.
+//
+// 사용:
+//
+//	go run ./cmd/holybean-server                         # 메모리 저장소 (인터넷 불필요)
+//	go run ./cmd/holybean-server -menu menu.json         # GET /menu 응답을 저장해 둔 파일로 메뉴 채우기
+//	go run ./cmd/holybean-server -store dynamodb         # 실제 DynamoDB (AWS_ENDPOINT_URL로 DynamoDB Local 지정 가능)
+//
+// VALID_API_KEY 환경 변수(또는 -api-key)가 있으면 auth_api_key 권한 부여자를 모든 경로 앞에 둡니다.
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/SIKU-KR/HolyBean/_legacy/aws-go/handler"
+	"github.com/SIKU-KR/HolyBean/_legacy/aws-go/holybean"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+type route struct {
+	method  string
+	path    string // API Gateway 리소스 경로. {name}은 경로 파라미터입니다.
+	handler handler.Func
+}
+
+func routes(orders holybean.OrderRepository, menus holybean.MenuRepository) []route {
+	return []route{
+		{"POST", "/order", handler.PostOrder(orders)},
+		{"GET", "/order", handler.GetOrderItemSpecific(orders)},
+		{"PUT", "/order", handler.AmendOrder(orders)},
+		{"DELETE", "/order", handler.DeleteOrder(orders)},
+		{"POST", "/order/restore", handler.RestoreOrder(orders)},
+		{"GET", "/order/num", handler.GetCurrentOrderNum(orders)},
+		{"GET", "/orders/{orderdate}", handler.GetOrderDay(orders)},
+		{"GET", "/report", handler.GetReport(orders)},
+		{"GET", "/credits", handler.GetCreditsList(orders)},
+		{"PUT", "/credits/{orderDate}/{number}", handler.UpdateCreditStatus(orders)},
+		{"POST", "/credits/{orderDate}/{number}/unsettle", handler.UnsettleCredit(orders)},
+		{"POST", "/credits/settle", handler.SettleCredits(orders)},
+		{"GET", "/credits/aging", handler.GetCreditAging(orders)},
+		{"GET", "/credits/customers", handler.GetCreditAccounts(orders)},
+		{"GET", "/credits/customers/{customer}", handler.GetCreditStatement(orders)},
+		{"GET", "/menu", handler.GetLastMenuList(menus)},
+		{"POST", "/menu", handler.SaveMenuList(menus)},
+		{"GET", "/menu/versions", handler.GetMenuVersions(menus)},
+		{"GET", "/menu/versions/{timestamp}", handler.GetMenuVersion(menus)},
+		{"POST", "/menu/versions/{timestamp}/restore", handler.RestoreMenuVersion(menus)},
+		{"GET", "/menu/diff", handler.GetMenuDiff(menus)},
+	}
+}
+
+func main() {
+	addr := flag.String("addr", ":8080", "listen address")
+	store := flag.String("store", "memory", "storage backend: memory or dynamodb")
+	menuFile := flag.String("menu", "", "JSON file in GET /menu response shape to seed the memory menu store")
+	apiKey := flag.String("api-key", os.Getenv("VALID_API_KEY"), "API key required in the apikey header (empty disables auth)")
+	flag.Parse()
+
+	orders, menus, err := openStore(*store, *menuFile)
+	if err != nil {
+		log.Fatalf("store 초기화 실패: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	for _, rt := range routes(orders, menus) {
+		mux.Handle(rt.method+" "+rt.path, adapt(rt.path, rt.handler))
+	}
+
+	var h http.Handler = mux
+	if *apiKey != "" {
+		h = authorize(handler.AuthAPIKey(*apiKey), mux)
+	}
+
+	log.Printf("holybean-server listening on %s (store=%s)", *addr, *store)
+	log.Fatal(http.ListenAndServe(*addr, logRequests(h)))
+}
+
+func openStore(store, menuFile string) (holybean.OrderRepository, holybean.MenuRepository, error) {
+	switch store {
+	case "dynamodb":
+		client, err := holybean.NewDynamoDBClient(context.Background())
+		if err != nil {
+			return nil, nil, err
+		}
+		return holybean.NewDynamoOrderRepository(client), holybean.NewDynamoMenuRepository(client), nil
+	case "memory":
+		var versions []holybean.Menu
+		if menuFile != "" {
+			version, err := readMenuFile(menuFile)
+			if err != nil {
+				return nil, nil, err
+			}
+			versions = append(versions, version)
+		}
+		return holybean.NewMemoryOrderRepository(), holybean.NewMemoryMenuRepository(versions...), nil
+	default:
+		return nil, nil, fmt.Errorf("unknown store %q", store)
+	}
+}
+
+// readMenuFile은 GET /menu 응답 형식({"timestamp", "menulist"})의 파일을 읽습니다.
+// menulist는 save_menulist와 같은 규칙으로 검증합니다.
+func readMenuFile(path string) (holybean.Menu, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return holybean.Menu{}, err
+	}
+	var file struct {
+		Timestamp string          `json:"timestamp"`
+		MenuList  json.RawMessage `json:"menulist"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return holybean.Menu{}, err
+	}
+	if len(file.MenuList) == 0 {
+		return holybean.Menu{}, fmt.Errorf("%s: menulist is missing", path)
+	}
+	request, err := holybean.DecodeMenuRequest([]byte(`{"menulist":` + string(file.MenuList) + `}`))
+	if err != nil {
+		return holybean.Menu{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if errs := request.Validate(); len(errs) > 0 {
+		return holybean.Menu{}, fmt.Errorf("%s: invalid menu: %+v", path, errs)
+	}
+	return holybean.Menu{Timestamp: file.Timestamp, Entries: request.Entries()}, nil
+}
+
+var pathParamPattern = regexp.MustCompile(`\{(\w+)\}`)
+
+// adapt는 http.Request를 API Gateway 프록시 이벤트로 바꿔 핸들러를 호출하고, 응답을 그대로 씁니다.
+func adapt(resource string, h handler.Func) http.Handler {
+	var paramNames []string
+	for _, m := range pathParamPattern.FindAllStringSubmatch(resource, -1) {
+		paramNames = append(paramNames, m[1])
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request, err := toProxyRequest(r, resource)
+		if err != nil {
+			log.Printf("read request: %v", err)
+			writeError(w, newRequestID(), holybean.CodeInvalidBody)
+			return
+		}
+		if len(paramNames) > 0 {
+			request.PathParameters = make(map[string]string, len(paramNames))
+			for _, name := range paramNames {
+				request.PathParameters[name] = r.PathValue(name)
+			}
+		}
+
+		response, err := h(r.Context(), request)
+		if err != nil {
+			// Lambda 런타임이 핸들러 오류를 502로 돌려주는 것과 맞춥니다.
+			log.Printf("[%s] handler error: %v", request.RequestContext.RequestID, err)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadGateway)
+			json.NewEncoder(w).Encode(holybean.NewErrorResponse(request.RequestContext.RequestID, holybean.CodeInternal))
+			return
+		}
+		writeProxyResponse(w, response)
+	})
+}
+
+func toProxyRequest(r *http.Request, resource string) (events.APIGatewayProxyRequest, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return events.APIGatewayProxyRequest{}, err
+	}
+
+	headers := make(map[string]string, len(r.Header))
+	for name, values := range r.Header {
+		headers[name] = strings.Join(values, ",")
+	}
+	var query map[string]string
+	if values := r.URL.Query(); len(values) > 0 {
+		query = make(map[string]string, len(values))
+		for name := range values {
+			query[name] = values.Get(name)
+		}
+	}
+
+	return events.APIGatewayProxyRequest{
+		Resource:                        resource,
+		Path:                            r.URL.Path,
+		HTTPMethod:                      r.Method,
+		Headers:                         headers,
+		MultiValueHeaders:               r.Header,
+		QueryStringParameters:           query,
+		MultiValueQueryStringParameters: r.URL.Query(),
+		Body:                            string(body),
+		RequestContext: events.APIGatewayProxyRequestContext{
+			RequestID:    newRequestID(),
+			Stage:        "local",
+			ResourcePath: resource,
+			HTTPMethod:   r.Method,
+			Path:         r.URL.Path,
+			Identity:     events.APIGatewayRequestIdentity{SourceIP: r.RemoteAddr},
+		},
+	}, nil
+}
+
+func writeProxyResponse(w http.ResponseWriter, response events.APIGatewayProxyResponse) {
+	for name, value := range response.Headers {
+		w.Header().Set(name, value)
+	}
+	for name, values := range response.MultiValueHeaders {
+		for _, value := range values {
+			w.Header().Add(name, value)
+		}
+	}
+	body := []byte(response.Body)
+	if response.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(response.Body)
+		if err != nil {
+			http.Error(w, "invalid base64 response body", http.StatusBadGateway)
+			return
+		}
+		body = decoded
+	}
+	w.WriteHeader(response.StatusCode)
+	w.Write(body)
+}
+
+// authorize는 auth_api_key 권한 부여자를 통과한 요청만 next로 넘깁니다.
+func authorize(authorizer func(context.Context, events.APIGatewayProxyRequest) (handler.AuthResponse, error), next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := events.APIGatewayProxyRequest{Headers: map[string]string{"apikey": r.Header.Get("apikey")}}
+		result, err := authorizer(r.Context(), request)
+		if err != nil || !result.IsAuthorized {
+			writeError(w, newRequestID(), holybean.CodeForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// writeError는 핸들러를 거치지 않은 요청에도 핸들러와 같은 오류 본문을 씁니다.
+func writeError(w http.ResponseWriter, requestID string, code holybean.ErrorCode) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code.Status())
+	json.NewEncoder(w).Encode(holybean.NewErrorResponse(requestID, code))
+}
+
+func logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		log.Printf("%s %s %d %s", r.Method, r.URL.RequestURI(), rec.status, time.Since(start).Round(time.Millisecond))
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
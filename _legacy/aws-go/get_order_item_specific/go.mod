@@ -1,24 +0,0 @@
-module get_order_item_specific
-
-    go 1.23.2
-
-    require (
-    github.com/aws/aws-lambda-go v1.49.0 // indirect
-    github.com/aws/aws-sdk-go-v2 v1.38.3 // indirect
-    github.com/aws/aws-sdk-go-v2/config v1.31.6 // indirect
-    github.com/aws/aws-sdk-go-v2/credentials v1.18.10 // indirect
-    github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue v1.20.9 // indirect
-    github.com/aws/aws-sdk-go-v2/feature/ec2/imds v1.18.6 // indirect
-    github.com/aws/aws-sdk-go-v2/internal/configsources v1.4.6 // indirect
-    github.com/aws/aws-sdk-go-v2/internal/endpoints/v2 v2.7.6 // indirect
-    github.com/aws/aws-sdk-go-v2/internal/ini v1.8.3 // indirect
-    github.com/aws/aws-sdk-go-v2/service/dynamodb v1.50.1 // indirect
-    github.com/aws/aws-sdk-go-v2/service/dynamodbstreams v1.30.2 // indirect
-    github.com/aws/aws-sdk-go-v2/service/internal/accept-encoding v1.13.1 // indirect
-    github.com/aws/aws-sdk-go-v2/service/internal/endpoint-discovery v1.11.6 // indirect
-    github.com/aws/aws-sdk-go-v2/service/internal/presigned-url v1.13.6 // indirect
-    github.com/aws/aws-sdk-go-v2/service/sso v1.29.1 // indirect
-    github.com/aws/aws-sdk-go-v2/service/ssooidc v1.34.2 // indirect
-    github.com/aws/aws-sdk-go-v2/service/sts v1.38.2 // indirect
-    github.com/aws/smithy-go v1.23.0 // indirect
-    )
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/SIKU-KR/HolyBean/_legacy/aws-go/holybean"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// amendOrderRequest는 amend_order 요청 본문입니다. 주문 생성 본문에 읽은 주문의 version을 더합니다.
+type amendOrderRequest struct {
+	holybean.OrderRequest
+	Version *int `json:"version"`
+}
+
+type amendOrderResponse struct {
+	Message     string         `json:"message"`
+	AmendedItem holybean.Order `json:"amendedItem"`
+}
+
+type versionConflictResponse struct {
+	holybean.ErrorResponse
+	CurrentOrder holybean.Order `json:"currentOrder"`
+}
+
+// AmendOrder는 쿼리 파라미터 orderDate, orderNum 주문의 항목, 결제 수단, 손님 이름, 외상 상태를 본문 내용으로 바꿉니다.
+// 본문은 post_order와 같고 같은 검증을 거치며, 읽은 주문의 version이 함께 있어야 합니다.
+// creditStatus를 1에서 0으로 바꾸면 오늘 영업일에 남은 외상을 받은 것으로, 0에서 1로 바꾸면 정산 취소로 외상 이력에 남습니다.
+// 외상 입금 기록이 있는 주문은 고칠 수 없습니다(409).
+// 그 사이 다른 수정이 있었으면 409(VERSION_CONFLICT)와 지금 주문을 반환합니다.
+// 고치기 전 내용은 주문의 revisions에 X-Operator 헤더의 직원과 함께 남습니다.
+func AmendOrder(orders holybean.OrderRepository) Func {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		orderDate, orderNum, perr := orderKeyParams(request.QueryStringParameters)
+		if perr != nil {
+			return fail(request, perr.code, perr.detail)
+		}
+
+		var body amendOrderRequest
+		if err := json.Unmarshal([]byte(request.Body), &body); err != nil {
+			log.Printf("요청 본문 파싱 오류: %v", err)
+			return fail(request, holybean.CodeInvalidBody)
+		}
+		if !body.HasRequiredFields() || body.Version == nil {
+			return fail(request, holybean.CodeMissingField)
+		}
+		errs := body.Validate()
+		if body.OrderNum != nil && *body.OrderNum != orderNum {
+			errs = append(errs, holybean.FieldError{
+				Field:   "orderNum",
+				Message: fmt.Sprintf("주문 번호는 바꿀 수 없습니다 (%d → %d)", orderNum, *body.OrderNum),
+			})
+		}
+		if len(errs) > 0 {
+			log.Printf("주문 검증 실패: %+v", errs)
+			return fail(request, holybean.CodeValidationFailed, errs...)
+		}
+
+		now := time.Now()
+		changes := body.ToOrder(orderDate)
+		amended, err := orders.Amend(ctx, orderDate, orderNum, holybean.OrderAmendment{
+			TotalAmount:    changes.TotalAmount,
+			CustomerName:   changes.CustomerName,
+			PaymentMethods: changes.PaymentMethods,
+			OrderItems:     changes.OrderItems,
+			CreditStatus:   changes.CreditStatus,
+			Version:        *body.Version,
+			At:             holybean.FormatCreatedAt(now),
+			Operator:       strings.TrimSpace(header(request, OperatorHeader)),
+			Date:           holybean.BusinessDate(now),
+		})
+		var conflict *holybean.VersionConflictError
+		switch {
+		case errors.Is(err, holybean.ErrOrderNotFound):
+			return fail(request, holybean.CodeOrderNotFound)
+		case errors.As(err, &conflict):
+			return holybean.JSONResponse(holybean.CodeVersionConflict.Status(), versionConflictResponse{
+				ErrorResponse: holybean.NewErrorResponse(request.RequestContext.RequestID, holybean.CodeVersionConflict, holybean.FieldError{
+					Field:   "version",
+					Message: fmt.Sprintf("주문이 version %d로 바뀌었습니다 (요청 version %d)", conflict.Current.Version, conflict.Expected),
+				}),
+				CurrentOrder: conflict.Current,
+			})
+		case errors.Is(err, holybean.ErrOrderVoided):
+			return fail(request, holybean.CodeInvalidState,
+				holybean.FieldError{Field: "orderNum", Message: "취소된 주문은 고칠 수 없습니다"})
+		case errors.Is(err, holybean.ErrNotCredit):
+			return fail(request, holybean.CodeInvalidState,
+				holybean.FieldError{Field: "creditStatus", Message: "외상 결제 수단이 없는 주문은 외상으로 바꿀 수 없습니다"})
+		case errors.Is(err, holybean.ErrCreditRecorded):
+			return fail(request, holybean.CodeInvalidState,
+				holybean.FieldError{Field: "creditStatus", Message: "외상 입금 기록이 있는 주문은 고칠 수 없습니다"})
+		case err != nil:
+			return internalError(request, "주문 수정 중 오류 발생", err)
+		}
+
+		return holybean.JSONResponse(200, amendOrderResponse{
+			Message:     "주문이 수정되었습니다.",
+			AmendedItem: amended,
+		})
+	}
+}
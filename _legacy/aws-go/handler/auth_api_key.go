@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// AuthResponse는 HTTP API Lambda 권한 부여자의 단순 응답 형식입니다.
+type AuthResponse struct {
+	IsAuthorized bool `json:"isAuthorized"`
+}
+
+// AuthAPIKey는 apikey 헤더가 validAPIKey와 같은지 확인하는 권한 부여자입니다.
+func AuthAPIKey(validAPIKey string) func(ctx context.Context, request events.APIGatewayProxyRequest) (AuthResponse, error) {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (AuthResponse, error) {
+		apiKeyFromRequest := request.Headers["apikey"]
+		if apiKeyFromRequest == "" {
+			apiKeyFromRequest = request.Headers["Apikey"]
+		}
+		if apiKeyFromRequest == "" {
+			apiKeyFromRequest = request.Headers["APIKEY"]
+		}
+		return AuthResponse{IsAuthorized: apiKeyFromRequest == validAPIKey}, nil
+	}
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SIKU-KR/HolyBean/_legacy/aws-go/holybean"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+type deleteOrderResponse struct {
+	Message     string         `json:"message"`
+	DeletedItem holybean.Order `json:"deletedItem"`
+}
+
+// DeleteOrder는 쿼리 파라미터 orderDate, orderNum으로 지정한 주문을 취소합니다.
+// 주문은 지우지 않고 취소 시각, X-Operator 헤더의 직원, reason 쿼리 파라미터를 기록해 남기며,
+// 취소된 주문은 리포트·하루 주문 목록·외상 목록에서 빠집니다. restore_order로 되살릴 수 있습니다.
+func DeleteOrder(orders holybean.OrderRepository) Func {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		orderDate, orderNum, perr := orderKeyParams(request.QueryStringParameters)
+		if perr != nil {
+			return fail(request, perr.code, perr.detail)
+		}
+
+		deleted, err := orders.Void(ctx, orderDate, orderNum, holybean.OrderVoid{
+			At:       holybean.FormatCreatedAt(time.Now()),
+			Operator: strings.TrimSpace(header(request, OperatorHeader)),
+			Reason:   strings.TrimSpace(request.QueryStringParameters["reason"]),
+		})
+		switch {
+		case errors.Is(err, holybean.ErrOrderNotFound):
+			return fail(request, holybean.CodeOrderNotFound)
+		case errors.Is(err, holybean.ErrOrderVoided):
+			return fail(request, holybean.CodeInvalidState,
+				holybean.FieldError{Field: "orderNum", Message: "이미 취소된 주문입니다"})
+		case err != nil:
+			return internalError(request, "주문 삭제 중 오류 발생", err)
+		}
+
+		return holybean.JSONResponse(200, deleteOrderResponse{
+			Message:     "주문이 성공적으로 삭제되었습니다.",
+			DeletedItem: deleted,
+		})
+	}
+}
+
+// orderKeyParams는 쿼리 파라미터 orderDate, orderNum을 주문 키로 읽습니다.
+func orderKeyParams(params map[string]string) (string, int, *paramError) {
+	orderDate := params["orderDate"]
+	orderNumStr := params["orderNum"]
+	if orderDate == "" || orderNumStr == "" {
+		return "", 0, &paramError{holybean.CodeMissingField,
+			holybean.FieldError{Field: "orderDate", Message: "orderDate와 orderNum이 모두 필요합니다"}}
+	}
+	orderNum, err := strconv.Atoi(orderNumStr)
+	if err != nil {
+		return "", 0, &paramError{holybean.CodeInvalidParameter,
+			holybean.FieldError{Field: "orderNum", Message: "orderNum은 정수여야 합니다: " + orderNumStr}}
+	}
+	return orderDate, orderNum, nil
+}
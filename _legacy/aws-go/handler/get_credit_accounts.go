@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/SIKU-KR/HolyBean/_legacy/aws-go/holybean"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// GetCreditAccounts는 미정산 외상을 손님(holybean.CustomerKey)별로 묶어
+// 남은 금액, 가장 오래된 미정산 날짜, 주문 수를 남은 금액이 큰 손님부터 반환합니다.
+func GetCreditAccounts(orders holybean.OrderRepository) Func {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		credits, err := orders.ListOpenCredits(ctx)
+		if err != nil {
+			return internalError(request, "Error listing open credits", err)
+		}
+		return holybean.JSONResponse(200, holybean.GroupCredits(credits))
+	}
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/SIKU-KR/HolyBean/_legacy/aws-go/holybean"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// exportFormatAOA는 대시보드가 SheetJS aoa_to_sheet로 바로 엑셀 시트를 만들 수 있는 2차원 배열 형식(format=aoa)입니다.
+const exportFormatAOA = "aoa"
+
+type exportSheet struct {
+	Name string          `json:"name"`
+	Rows [][]interface{} `json:"rows"`
+}
+
+type exportResponse struct {
+	FileName string        `json:"fileName"`
+	Sheets   []exportSheet `json:"sheets"`
+}
+
+// GetCreditAging은 미정산 외상의 남은 금액을 orderDate부터 지난 날 수로 0–7, 8–30, 31–90, 90일 초과 구간에 나눠
+// 구간별 합계·건수와 손님별 내역을 반환합니다. 기준일은 쿼리 파라미터 asOf(기본: 오늘 영업일)입니다.
+// format=aoa이면 시트별 2차원 배열(exportResponse)을 반환합니다.
+func GetCreditAging(orders holybean.OrderRepository) Func {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		params := request.QueryStringParameters
+		asOf, perr := parseDate("asOf", orDefault(params["asOf"], holybean.Today()))
+		if perr != nil {
+			return fail(request, perr.code, perr.detail)
+		}
+		format := params["format"]
+		if format != "" && format != exportFormatAOA {
+			return fail(request, holybean.CodeInvalidParameter,
+				holybean.FieldError{Field: "format", Message: "format은 aoa만 쓸 수 있습니다"})
+		}
+
+		credits, err := orders.ListOpenCredits(ctx)
+		if err != nil {
+			return internalError(request, "Error listing open credits", err)
+		}
+		report := holybean.AgeCredits(credits, asOf)
+		if format == exportFormatAOA {
+			return holybean.JSONResponse(200, agingExport(report))
+		}
+		return holybean.JSONResponse(200, report)
+	}
+}
+
+// agingExport는 연령 구간 요약 시트와 손님별 시트를 만듭니다. 각 시트는 헤더 + 행 + 합계 행입니다.
+func agingExport(report holybean.AgingReport) exportResponse {
+	summary := [][]interface{}{{"구간(일)", "건수", "금액"}}
+	for _, bucket := range report.Buckets {
+		summary = append(summary, []interface{}{bucket.Label, bucket.Count, bucket.Outstanding})
+	}
+	summary = append(summary, []interface{}{"합계", report.OrderCount, report.Outstanding})
+
+	header := []interface{}{"고객명"}
+	for _, bucket := range report.Buckets {
+		header = append(header, bucket.Label+"일")
+	}
+	header = append(header, "합계", "건수", "가장 오래된 외상")
+	customers := [][]interface{}{header}
+	for _, customer := range report.Customers {
+		name := customer.CustomerName
+		if name == "" {
+			name = "-"
+		}
+		row := []interface{}{name}
+		for _, bucket := range customer.Buckets {
+			row = append(row, bucket.Outstanding)
+		}
+		customers = append(customers, append(row, customer.Outstanding, customer.OrderCount, customer.OldestUnpaidDate))
+	}
+	total := []interface{}{"합계"}
+	for _, bucket := range report.Buckets {
+		total = append(total, bucket.Outstanding)
+	}
+	customers = append(customers, append(total, report.Outstanding, report.OrderCount, ""))
+
+	return exportResponse{
+		FileName: "holybean-credit-aging-" + report.AsOf + ".xlsx",
+		Sheets: []exportSheet{
+			{Name: "외상연령", Rows: summary},
+			{Name: "고객별", Rows: customers},
+		},
+	}
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/SIKU-KR/HolyBean/_legacy/aws-go/holybean"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+type statementOrder struct {
+	OrderDate      string                   `json:"orderDate"`
+	OrderNum       int                      `json:"orderNum"`
+	TotalAmount    holybean.Won             `json:"totalAmount"`
+	Paid           holybean.Won             `json:"paid"`
+	Balance        holybean.Won             `json:"balance"`
+	OrderItems     []holybean.OrderItem     `json:"orderItems"`
+	CreditPayments []holybean.CreditPayment `json:"creditPayments,omitempty"`
+}
+
+type creditStatementResponse struct {
+	holybean.CreditAccount
+	Orders []statementOrder `json:"orders"`
+}
+
+// GetCreditStatement는 경로 파라미터 {customer} 손님의 미정산 외상 주문을 메뉴와 입금 내역까지 반환합니다.
+// {customer}는 이름 그대로 줘도 되고 GetCreditAccounts의 customerKey를 줘도 됩니다. 같은 규칙으로 정규화해 찾습니다.
+func GetCreditStatement(orders holybean.OrderRepository) Func {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		// API Gateway와 로컬 서버 모두 경로 파라미터를 이미 디코딩해 넘깁니다.
+		customer := request.PathParameters["customer"]
+		if holybean.CustomerKey(customer) == "" {
+			return fail(request, holybean.CodeMissingField,
+				holybean.FieldError{Field: "customer", Message: "경로에 손님 이름이 필요합니다"})
+		}
+		key := holybean.CustomerKey(customer)
+
+		credits, err := orders.ListOpenCredits(ctx)
+		if err != nil {
+			return internalError(request, "Error listing open credits", err)
+		}
+		// 미정산 외상이 없는 손님도 오류가 아니라 빈 명세서입니다.
+		statement := creditStatementResponse{
+			CreditAccount: holybean.CreditAccount{CustomerKey: key, CustomerName: customer},
+			Orders:        []statementOrder{},
+		}
+		for _, account := range holybean.GroupCredits(credits) {
+			if account.CustomerKey != key {
+				continue
+			}
+			statement.CreditAccount = account
+			for _, order := range account.Orders {
+				statement.Orders = append(statement.Orders, statementOrder{
+					OrderDate:      order.OrderDate,
+					OrderNum:       order.OrderNum,
+					TotalAmount:    order.TotalAmount,
+					Paid:           order.Paid(),
+					Balance:        order.Balance(),
+					OrderItems:     order.OrderItems,
+					CreditPayments: order.CreditPayments,
+				})
+			}
+		}
+		return holybean.JSONResponse(200, statement)
+	}
+}
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+
+	"github.com/SIKU-KR/HolyBean/_legacy/aws-go/holybean"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// 외상 목록 한 페이지의 기본·최대 크기입니다.
+const (
+	defaultCreditPageSize = 50
+	maxCreditPageSize     = 200
+)
+
+type creditItem struct {
+	TotalAmount  holybean.Won `json:"totalAmount"`
+	Paid         holybean.Won `json:"paid"`
+	Balance      holybean.Won `json:"balance"`
+	OrderNum     int          `json:"orderNum"`
+	OrderDate    string       `json:"orderDate"`
+	CustomerName string       `json:"customerName"`
+}
+
+type creditPageResponse struct {
+	Items      []creditItem `json:"items"`
+	NextCursor string       `json:"nextCursor,omitempty"`
+}
+
+// GetCreditsList는 아직 결제되지 않은 외상 주문 목록을 반환합니다.
+// 일부만 갚은 주문은 paid와 남은 금액 balance로 알 수 있습니다.
+//
+// 쿼리 파라미터(모두 선택):
+//   - customer: 손님 이름 일부, start·end: orderDate 범위, minAmount: 남은 금액 최솟값
+//   - sort: date(기본)·amount·customer, order: asc(기본)·desc
+//   - limit, cursor: limit을 주면 {items, nextCursor} 모양으로 한 페이지씩 반환합니다.
+//     다음 페이지는 같은 조건에 nextCursor를 cursor로 넘겨 받습니다. nextCursor가 없으면 마지막 페이지입니다.
+//
+// limit이 없으면 예전처럼 조건에 맞는 주문 전체를 배열로 반환합니다.
+//
+// 페이지마다 미정산 외상 전체(creditStatus-index)를 읽고 걸러 정렬합니다. 남은 금액(balance)은 장부로 계산하는 값이고
+// 손님 정렬은 이름을 정규화한 값이라, orderDate 순서뿐인 인덱스의 ExclusiveStartKey로는 이 정렬과 조건을 이어 갈 수 없습니다.
+// 인덱스에는 정산되지 않은 주문만 있어 많아야 수백 건이므로 페이지마다 한 번 읽어도 괜찮습니다.
+// 커서는 읽는 양을 줄이려는 것이 아니라, 그 사이 앞쪽 주문이 정산되어도 건너뛰거나 겹치지 않게 위치를 기억하는 것입니다.
+func GetCreditsList(orders holybean.OrderRepository) Func {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		params := request.QueryStringParameters
+		filter := holybean.CreditFilter{Customer: params["customer"], Start: params["start"], End: params["end"]}
+		if filter.Customer != "" && holybean.CustomerKey(filter.Customer) == "" {
+			return fail(request, holybean.CodeInvalidParameter,
+				holybean.FieldError{Field: "customer", Message: "customer에 공백과 '님' 말고 이름이 있어야 합니다"})
+		}
+		for _, param := range []string{"start", "end"} {
+			if value := params[param]; value != "" {
+				if _, perr := parseDate(param, value); perr != nil {
+					return fail(request, perr.code, perr.detail)
+				}
+			}
+		}
+		if value := params["minAmount"]; value != "" {
+			amount, err := holybean.ParseWon(value)
+			if err != nil {
+				return fail(request, holybean.CodeInvalidParameter,
+					holybean.FieldError{Field: "minAmount", Message: "minAmount는 원 단위 정수여야 합니다: " + value})
+			}
+			filter.MinBalance = amount
+		}
+
+		by := holybean.CreditSort(orDefault(params["sort"], string(holybean.CreditSortDate)))
+		switch by {
+		case holybean.CreditSortDate, holybean.CreditSortAmount, holybean.CreditSortCustomer:
+		default:
+			return fail(request, holybean.CodeInvalidParameter,
+				holybean.FieldError{Field: "sort", Message: "sort는 date, amount, customer 중 하나여야 합니다"})
+		}
+		var desc bool
+		switch params["order"] {
+		case "", "asc":
+		case "desc":
+			desc = true
+		default:
+			return fail(request, holybean.CodeInvalidParameter,
+				holybean.FieldError{Field: "order", Message: "order는 asc 또는 desc여야 합니다"})
+		}
+
+		paged := params["limit"] != ""
+		limit := 0
+		if paged {
+			var err error
+			if limit, err = strconv.Atoi(params["limit"]); err != nil || limit <= 0 || limit > maxCreditPageSize {
+				return fail(request, holybean.CodeInvalidParameter, holybean.FieldError{
+					Field:   "limit",
+					Message: "limit은 1~" + strconv.Itoa(maxCreditPageSize) + " 사이 정수여야 합니다",
+				})
+			}
+		} else if params["cursor"] != "" {
+			limit, paged = defaultCreditPageSize, true
+		}
+		var after *holybean.CreditCursor
+		if token := params["cursor"]; token != "" {
+			cursor, err := holybean.DecodeCreditCursor(token)
+			if err != nil {
+				return fail(request, holybean.CodeInvalidParameter,
+					holybean.FieldError{Field: "cursor", Message: "cursor가 올바르지 않습니다"})
+			}
+			after = &cursor
+		}
+
+		credits, err := orders.ListOpenCredits(ctx)
+		if err != nil {
+			return internalError(request, "Error listing open credits", err)
+		}
+		var matched []holybean.Order
+		for _, order := range credits {
+			if filter.Match(order) {
+				matched = append(matched, order)
+			}
+		}
+		page, next, err := holybean.PageCredits(matched, by, desc, after, limit)
+		if errors.Is(err, holybean.ErrInvalidCursor) {
+			return fail(request, holybean.CodeInvalidParameter,
+				holybean.FieldError{Field: "cursor", Message: "다른 sort·order로 만든 cursor입니다"})
+		}
+		if err != nil {
+			return internalError(request, "Error paging credits", err)
+		}
+		log.Printf("Found %d credit items, returning %d", len(matched), len(page))
+
+		items := make([]creditItem, len(page))
+		for i, order := range page {
+			items[i] = creditItem{
+				CustomerName: order.CustomerName,
+				TotalAmount:  order.TotalAmount,
+				Paid:         order.Paid(),
+				Balance:      order.Balance(),
+				OrderNum:     order.OrderNum,
+				OrderDate:    order.OrderDate,
+			}
+		}
+		if !paged {
+			// Return as direct array (not wrapped in an object)
+			return holybean.JSONResponse(200, items)
+		}
+		response := creditPageResponse{Items: items}
+		if next != nil {
+			response.NextCursor = next.Encode()
+		}
+		return holybean.JSONResponse(200, response)
+	}
+}
+
+// orDefault는 value가 비어 있으면 fallback을 반환합니다.
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
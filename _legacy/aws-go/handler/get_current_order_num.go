@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"context"
+	"log"
+
+	"github.com/SIKU-KR/HolyBean/_legacy/aws-go/holybean"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+type nextOrderNumResponse struct {
+	NextOrderNum int `json:"nextOrderNum"`
+}
+
+// GetCurrentOrderNum은 오늘 영업일의 주문 번호 카운터에서 번호 하나를 받아 반환합니다.
+// 호출할 때마다 새 번호를 예약하므로 두 기기가 동시에 물어도 같은 번호를 받지 않습니다.
+// 예약한 번호로 주문하지 않으면 그 번호는 비어 있게 됩니다.
+func GetCurrentOrderNum(orders holybean.OrderRepository) Func {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		todayDate := holybean.Today()
+
+		nextOrderNum, err := holybean.AllocateOrderNum(ctx, orders, todayDate)
+		if err != nil {
+			return internalError(request, "Error allocating order number", err)
+		}
+		log.Printf("Next order number for %s: %d", todayDate, nextOrderNum)
+		return holybean.JSONResponse(200, nextOrderNumResponse{NextOrderNum: nextOrderNum})
+	}
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/SIKU-KR/HolyBean/_legacy/aws-go/holybean"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+type menuListResponse struct {
+	Timestamp string               `json:"timestamp"`
+	MenuList  []holybean.MenuEntry `json:"menulist"`
+	// InvalidEntries는 형식이 잘못돼 menulist에서 뺀 저장 항목입니다.
+	InvalidEntries []holybean.FieldError `json:"invalidEntries,omitempty"`
+}
+
+// inUseOnlyParam은 inuse=false 메뉴를 응답에서 빼는 쿼리 파라미터입니다. (?inUseOnly=true)
+const inUseOnlyParam = "inUseOnly"
+
+// newMenuListResponse는 메뉴를 placement 순으로 정렬하고, 요청하면 사용하지 않는 메뉴를 뺍니다.
+func newMenuListResponse(request events.APIGatewayProxyRequest, menu holybean.Menu) menuListResponse {
+	if len(menu.Invalid) > 0 {
+		log.Printf("[%s] menu %s has %d invalid entries: %+v", request.RequestContext.RequestID, menu.Timestamp, len(menu.Invalid), menu.Invalid)
+	}
+	return menuListResponse{
+		Timestamp:      menu.Timestamp,
+		MenuList:       menu.Sorted(request.QueryStringParameters[inUseOnlyParam] == "true"),
+		InvalidEntries: menu.Invalid,
+	}
+}
+
+// GetLastMenuList는 가장 최근에 저장된 메뉴 버전을 placement 순으로 반환합니다.
+// ?inUseOnly=true이면 inuse=false인 메뉴를 뺍니다.
+func GetLastMenuList(menus holybean.MenuRepository) Func {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		latest, err := menus.Latest(ctx)
+		if errors.Is(err, holybean.ErrMenuNotFound) {
+			log.Printf("No menu items found")
+			return fail(request, holybean.CodeMenuNotFound)
+		}
+		if err != nil {
+			return internalError(request, "Error loading latest menu", err)
+		}
+		log.Printf("Latest menu: sk=%s, menu_items_count=%d", latest.Timestamp, len(latest.Entries))
+
+		return holybean.JSONResponse(200, newMenuListResponse(request, latest))
+	}
+}
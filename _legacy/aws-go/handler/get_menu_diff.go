@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/SIKU-KR/HolyBean/_legacy/aws-go/holybean"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+type menuDiffResponse struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	holybean.MenuDiff
+}
+
+// GetMenuDiff는 쿼리 파라미터 from 버전에서 to 버전으로 바뀐 메뉴를 반환합니다.
+// to를 생략하면 가장 최근 버전과 비교합니다.
+func GetMenuDiff(menus holybean.MenuRepository) Func {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		from := request.QueryStringParameters["from"]
+		to := request.QueryStringParameters["to"]
+		if from == "" {
+			return fail(request, holybean.CodeMissingField,
+				holybean.FieldError{Field: "from", Message: "from 파라미터가 필요합니다"})
+		}
+
+		fromVersion, err := menus.Get(ctx, from)
+		if errors.Is(err, holybean.ErrMenuNotFound) {
+			return fail(request, holybean.CodeMenuNotFound,
+				holybean.FieldError{Field: "from", Message: from + " 버전이 없습니다"})
+		}
+		if err != nil {
+			return internalError(request, "Error loading menu version", err)
+		}
+
+		var toVersion holybean.Menu
+		if to == "" {
+			toVersion, err = menus.Latest(ctx)
+		} else {
+			toVersion, err = menus.Get(ctx, to)
+		}
+		if errors.Is(err, holybean.ErrMenuNotFound) {
+			return fail(request, holybean.CodeMenuNotFound,
+				holybean.FieldError{Field: "to", Message: to + " 버전이 없습니다"})
+		}
+		if err != nil {
+			return internalError(request, "Error loading menu version", err)
+		}
+
+		return holybean.JSONResponse(200, menuDiffResponse{
+			From:     fromVersion.Timestamp,
+			To:       toVersion.Timestamp,
+			MenuDiff: holybean.DiffMenus(fromVersion.Entries, toVersion.Entries),
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/SIKU-KR/HolyBean/_legacy/aws-go/holybean"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// GetMenuVersion은 경로 파라미터 {timestamp}의 메뉴 버전을 GET /menu와 같은 모양으로 반환합니다.
+// ?inUseOnly=true도 GET /menu와 같이 동작합니다.
+func GetMenuVersion(menus holybean.MenuRepository) Func {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		timestamp := request.PathParameters["timestamp"]
+		if timestamp == "" {
+			return fail(request, holybean.CodeMissingField,
+				holybean.FieldError{Field: "timestamp", Message: "경로에 timestamp가 필요합니다"})
+		}
+
+		version, err := menus.Get(ctx, timestamp)
+		if errors.Is(err, holybean.ErrMenuNotFound) {
+			return fail(request, holybean.CodeMenuNotFound,
+				holybean.FieldError{Field: "timestamp", Message: timestamp + " 버전이 없습니다"})
+		}
+		if err != nil {
+			return internalError(request, "Error loading menu version", err)
+		}
+
+		return holybean.JSONResponse(200, newMenuListResponse(request, version))
+	}
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/SIKU-KR/HolyBean/_legacy/aws-go/holybean"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+type menuVersionSummary struct {
+	Timestamp    string `json:"timestamp"`
+	ItemCount    int    `json:"itemCount"`
+	RestoredFrom string `json:"restoredFrom,omitempty"`
+}
+
+// GetMenuVersions는 저장된 메뉴 버전 목록을 최신순으로 반환합니다. 메뉴 항목은 싣지 않습니다.
+func GetMenuVersions(menus holybean.MenuRepository) Func {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		versions, err := menus.List(ctx)
+		if err != nil {
+			return internalError(request, "Error listing menu versions", err)
+		}
+
+		summaries := make([]menuVersionSummary, len(versions))
+		for i, version := range versions {
+			summaries[i] = menuVersionSummary{
+				Timestamp:    version.Timestamp,
+				ItemCount:    len(version.Entries),
+				RestoredFrom: version.RestoredFrom,
+			}
+		}
+		return holybean.JSONResponse(200, summaries)
+	}
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/SIKU-KR/HolyBean/_legacy/aws-go/holybean"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+type orderSummary struct {
+	CustomerName string       `json:"customerName"`
+	TotalAmount  holybean.Won `json:"totalAmount"`
+	OrderMethod  string       `json:"orderMethod"`
+	OrderNum     int          `json:"orderNum"`
+	// Voided는 includeVoided=true로 조회했을 때 취소된 주문에만 붙습니다.
+	Voided *holybean.OrderVoid `json:"voided,omitempty"`
+}
+
+// GetOrderDay는 경로 파라미터 {orderdate} 날짜의 주문 요약 목록을 반환합니다.
+// 취소된 주문은 빠지며, 쿼리 파라미터 includeVoided=true를 주면 취소 기록과 함께 포함합니다.
+func GetOrderDay(orders holybean.OrderRepository) Func {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		orderDate := request.PathParameters["orderdate"]
+		if orderDate == "" {
+			return fail(request, holybean.CodeMissingField,
+				holybean.FieldError{Field: "orderdate", Message: "경로에 orderdate가 필요합니다"})
+		}
+
+		dayOrders, err := orders.ListByDate(ctx, orderDate)
+		if err != nil {
+			return internalError(request, "Error fetching orders", err)
+		}
+		if request.QueryStringParameters["includeVoided"] != "true" {
+			dayOrders = holybean.ActiveOrders(dayOrders)
+		}
+		if len(dayOrders) == 0 {
+			return fail(request, holybean.CodeOrderNotFound,
+				holybean.FieldError{Field: "orderdate", Message: orderDate + " 날짜의 주문이 없습니다"})
+		}
+
+		summaries := make([]orderSummary, len(dayOrders))
+		for i, order := range dayOrders {
+			summaries[i] = orderSummary{
+				CustomerName: order.CustomerName,
+				TotalAmount:  order.TotalAmount,
+				OrderMethod:  order.MethodLabel(),
+				OrderNum:     order.OrderNum,
+				Voided:       order.Voided,
+			}
+		}
+		return holybean.JSONResponse(200, summaries)
+	}
+}
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/SIKU-KR/HolyBean/_legacy/aws-go/holybean"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// GetOrderItemSpecific은 쿼리 파라미터 orderDate, orderNum으로 지정한 주문 한 건을 반환합니다.
+func GetOrderItemSpecific(orders holybean.OrderRepository) Func {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		orderDate, orderNum, perr := orderKeyParams(request.QueryStringParameters)
+		if perr != nil {
+			return fail(request, perr.code, perr.detail)
+		}
+
+		order, err := orders.Get(ctx, orderDate, orderNum)
+		if errors.Is(err, holybean.ErrOrderNotFound) {
+			return fail(request, holybean.CodeOrderNotFound)
+		}
+		if err != nil {
+			return internalError(request, "Error fetching order", err)
+		}
+		return holybean.JSONResponse(200, order)
+	}
+}
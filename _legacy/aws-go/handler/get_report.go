@@ -0,0 +1,288 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/SIKU-KR/HolyBean/_legacy/aws-go/holybean"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+const (
+	// reportModeCompare는 두 기간을 비교하는 리포트 모드(mode=compare)입니다.
+	reportModeCompare = "compare"
+	// reportModeTimeOfDay는 주문 시각 구간별 리포트 모드(mode=timeofday)입니다.
+	reportModeTimeOfDay = "timeofday"
+	// defaultIntervalMinutes는 timeofday 모드에서 interval을 생략했을 때의 구간 길이(분)입니다.
+	defaultIntervalMinutes = 15
+)
+
+type menuSale struct {
+	QuantitySold int          `json:"quantitySold"`
+	TotalSales   holybean.Won `json:"totalSales"`
+}
+
+type reportResponse struct {
+	MenuSales          map[string]menuSale     `json:"menuSales"`
+	PaymentMethodSales map[string]holybean.Won `json:"paymentMethodSales"`
+}
+
+type reportPeriod struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+	reportResponse
+	Weekly []holybean.WeekTotal `json:"weekly"`
+}
+
+type compareReportResponse struct {
+	Current            reportPeriod                             `json:"current"`
+	Previous           reportPeriod                             `json:"previous"`
+	MenuSales          map[string]holybean.MenuSalesChange      `json:"menuSales"`
+	PaymentMethodSales map[string]holybean.Change[holybean.Won] `json:"paymentMethodSales"`
+}
+
+type timeOfDayResponse struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+	holybean.TimeOfDay
+}
+
+// dateRange는 양 끝을 포함하는 날짜 기간입니다.
+type dateRange struct {
+	start, end time.Time
+}
+
+func (r dateRange) startDate() string { return r.start.Format(holybean.DateLayout) }
+func (r dateRange) endDate() string   { return r.end.Format(holybean.DateLayout) }
+
+// previous는 r 바로 앞의 같은 길이 기간입니다.
+func (r dateRange) previous() dateRange {
+	days := int(r.end.Sub(r.start).Hours()/24) + 1
+	return dateRange{start: r.start.AddDate(0, 0, -days), end: r.start.AddDate(0, 0, -1)}
+}
+
+// GetReport는 쿼리 파라미터 start~end 기간의 정산 완료 주문을 메뉴별·결제 수단별로 집계합니다.
+// 주문을 다시 읽지 않고, 쓰기 때마다 갱신되는 일별 집계(holybean.Rollup)를 더합니다.
+//
+// mode=compare이면 start~end를 compareStart~compareEnd 기간과 비교해 메뉴별·결제 수단별 증감과
+// 증감률, 두 기간의 주별 합계를 함께 반환합니다. 비교 기간을 생략하면 바로 앞의 같은 길이 기간과 비교합니다.
+//
+// mode=timeofday이면 기간의 주문을 받은 시각(Asia/Seoul) 기준 interval분(기본 15분) 구간별 주문 수와 매출을 반환합니다.
+// 하루만 보려면 start와 end를 같은 날로 줍니다.
+func GetReport(orders holybean.OrderRepository) Func {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		params := request.QueryStringParameters
+		current, perr := parseDateRange(params, "start", "end")
+		if perr != nil {
+			return fail(request, perr.code, perr.detail)
+		}
+
+		if params["mode"] == "" {
+			sum, err := sumRange(ctx, orders, current)
+			if err != nil {
+				return internalError(request, "Error listing rollups", err)
+			}
+			return holybean.JSONResponse(200, newReportResponse(sum))
+		}
+		if params["mode"] == reportModeTimeOfDay {
+			return timeOfDayReport(ctx, orders, request, current)
+		}
+		if params["mode"] != reportModeCompare {
+			return fail(request, holybean.CodeInvalidParameter,
+				holybean.FieldError{Field: "mode", Message: "mode는 compare 또는 timeofday여야 합니다"})
+		}
+
+		previous := current.previous()
+		if params["compareStart"] != "" || params["compareEnd"] != "" {
+			if previous, perr = parseDateRange(params, "compareStart", "compareEnd"); perr != nil {
+				return fail(request, perr.code, perr.detail)
+			}
+		}
+		currentPeriod, currentSum, err := periodReport(ctx, orders, current)
+		if err != nil {
+			return internalError(request, "Error listing rollups", err)
+		}
+		previousPeriod, previousSum, err := periodReport(ctx, orders, previous)
+		if err != nil {
+			return internalError(request, "Error listing rollups", err)
+		}
+
+		comparison := holybean.CompareRollups(currentSum, previousSum)
+		comparison.PaymentSales["총합"] = comparison.Total
+		return holybean.JSONResponse(200, compareReportResponse{
+			Current:            currentPeriod,
+			Previous:           previousPeriod,
+			MenuSales:          comparison.MenuSales,
+			PaymentMethodSales: comparison.PaymentSales,
+		})
+	}
+}
+
+// timeOfDayReport는 기간의 주문을 주문 시각 구간별로 모읍니다.
+// 구간은 일별 집계에 없으므로 주문을 직접 읽습니다.
+func timeOfDayReport(ctx context.Context, orders holybean.OrderRepository, request events.APIGatewayProxyRequest, period dateRange) (events.APIGatewayProxyResponse, error) {
+	interval := defaultIntervalMinutes
+	if value := request.QueryStringParameters["interval"]; value != "" {
+		var err error
+		if interval, err = strconv.Atoi(value); err != nil || interval <= 0 || 24*60%interval != 0 {
+			return fail(request, holybean.CodeInvalidParameter,
+				holybean.FieldError{Field: "interval", Message: "interval은 하루(1440분)를 나누어떨어지게 하는 분 단위 양수여야 합니다"})
+		}
+	}
+
+	list, err := orders.ListRange(ctx, period.startDate(), period.endDate())
+	if err != nil {
+		return internalError(request, "Error listing orders", err)
+	}
+	report, err := holybean.TimeOfDayBuckets(list, interval)
+	if err != nil {
+		return internalError(request, "Error bucketing orders", err)
+	}
+	return holybean.JSONResponse(200, timeOfDayResponse{
+		Start:     period.startDate(),
+		End:       period.endDate(),
+		TimeOfDay: report,
+	})
+}
+
+// paramError는 쿼리 파라미터가 잘못되었을 때 돌려줄 오류 코드와 필드입니다.
+type paramError struct {
+	code   holybean.ErrorCode
+	detail holybean.FieldError
+}
+
+// parseDateRange는 startParam~endParam 쿼리 파라미터를 기간으로 읽습니다.
+// 기간은 holybean.MaxRangeDays일까지입니다.
+func parseDateRange(params map[string]string, startParam, endParam string) (dateRange, *paramError) {
+	startDateStr, endDateStr := params[startParam], params[endParam]
+	if startDateStr == "" || endDateStr == "" {
+		return dateRange{}, &paramError{holybean.CodeMissingField,
+			holybean.FieldError{Field: startParam, Message: startParam + " 및 " + endParam + " 파라미터가 필요합니다"}}
+	}
+
+	startDate, perr := parseDate(startParam, startDateStr)
+	if perr != nil {
+		return dateRange{}, perr
+	}
+	endDate, perr := parseDate(endParam, endDateStr)
+	if perr != nil {
+		return dateRange{}, perr
+	}
+	if startDate.After(endDate) {
+		return dateRange{}, &paramError{holybean.CodeInvalidParameter,
+			holybean.FieldError{Field: startParam, Message: startParam + " 날짜는 " + endParam + " 날짜보다 이전이어야 합니다"}}
+	}
+	if days := int(endDate.Sub(startDate).Hours()/24) + 1; days > holybean.MaxRangeDays {
+		return dateRange{}, &paramError{holybean.CodeInvalidParameter,
+			holybean.FieldError{Field: endParam, Message: fmt.Sprintf("기간은 %d일을 넘을 수 없습니다: %d일", holybean.MaxRangeDays, days)}}
+	}
+	return dateRange{start: startDate, end: endDate}, nil
+}
+
+// parseDate는 param 쿼리 파라미터 값 value를 YYYY-MM-DD 날짜로 읽습니다.
+func parseDate(param, value string) (time.Time, *paramError) {
+	date, err := time.Parse(holybean.DateLayout, value)
+	if err != nil {
+		return time.Time{}, &paramError{holybean.CodeInvalidParameter,
+			holybean.FieldError{Field: param, Message: "날짜 형식은 YYYY-MM-DD 여야 합니다"}}
+	}
+	return date, nil
+}
+
+// sumRange는 기간의 일별 집계를 더합니다.
+// 일별 집계에는 정산 완료 주문만 들어 있으므로 더하기만 하면 됩니다.
+func sumRange(ctx context.Context, orders holybean.OrderRepository, period dateRange) (holybean.Rollup, error) {
+	rollups, err := listRollups(ctx, orders, period)
+	if err != nil {
+		return holybean.Rollup{}, err
+	}
+	return holybean.SumRollups(rollups), nil
+}
+
+// listRollups는 기간의 일별 집계를 날짜 오름차순으로 반환합니다.
+// 집계 항목이 없는 날은 holybean-rebuild로 채우기 전의 날짜일 수 있으므로 그날 주문에서 직접 계산합니다.
+// 항목이 있는 날은 그날 주문이 모두 들어 있습니다. 저장소가 항목이 없는 날짜에 처음 쓸 때 그날 주문으로 채우기 때문입니다.
+// 어느 쪽이든 다른 날 주문의 외상 입금은 holybean-rebuild를 돌려야 들어갑니다.
+// 채운 뒤에는 주문이 없던 날만 빈 Query 한 번씩을 더 씁니다.
+func listRollups(ctx context.Context, orders holybean.OrderRepository, period dateRange) ([]holybean.Rollup, error) {
+	rollups, err := orders.ListRollups(ctx, period.startDate(), period.endDate())
+	if err != nil {
+		return nil, err
+	}
+	stored := make(map[string]bool, len(rollups))
+	for _, rollup := range rollups {
+		stored[rollup.Date] = true
+	}
+
+	// 집계가 없는 날이 이어진 구간마다 주문을 읽어 계산합니다.
+	derived := holybean.NewDerived()
+	var missing []string
+	flush := func() error {
+		if len(missing) == 0 {
+			return nil
+		}
+		list, err := orders.ListRange(ctx, missing[0], missing[len(missing)-1])
+		if err != nil {
+			return err
+		}
+		for _, order := range list {
+			derived.Add(order)
+		}
+		missing = missing[:0]
+		return nil
+	}
+	var filled []string
+	for day := period.start; !day.After(period.end); day = day.AddDate(0, 0, 1) {
+		date := day.Format(holybean.DateLayout)
+		if stored[date] {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		missing = append(missing, date)
+		filled = append(filled, date)
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	for _, date := range filled {
+		if rollup, ok := derived.Rollups[date]; ok && !rollup.IsZero() {
+			rollups = append(rollups, rollup.Compact())
+		}
+	}
+	sort.Slice(rollups, func(i, j int) bool { return rollups[i].Date < rollups[j].Date })
+	return rollups, nil
+}
+
+// periodReport는 비교 리포트의 한 기간 요약과 주별 합계를 만듭니다.
+func periodReport(ctx context.Context, orders holybean.OrderRepository, period dateRange) (reportPeriod, holybean.Rollup, error) {
+	rollups, err := listRollups(ctx, orders, period)
+	if err != nil {
+		return reportPeriod{}, holybean.Rollup{}, err
+	}
+	sum := holybean.SumRollups(rollups)
+	return reportPeriod{
+		Start:          period.startDate(),
+		End:            period.endDate(),
+		reportResponse: newReportResponse(sum),
+		Weekly:         holybean.WeeklyTotals(period.start, period.end, rollups),
+	}, sum, nil
+}
+
+func newReportResponse(sum holybean.Rollup) reportResponse {
+	menuSales := make(map[string]menuSale, len(sum.MenuSales))
+	for name, sale := range sum.MenuSales {
+		menuSales[name] = menuSale{QuantitySold: sale.Quantity, TotalSales: sale.Sales}
+	}
+	paymentMethodSales := make(map[string]holybean.Won, len(sum.PaymentSales)+1)
+	for method, amount := range sum.PaymentSales {
+		paymentMethodSales[method] = amount
+	}
+	paymentMethodSales["총합"] = sum.Total
+	return reportResponse{MenuSales: menuSales, PaymentMethodSales: paymentMethodSales}
+}
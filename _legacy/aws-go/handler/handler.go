@@ -0,0 +1,41 @@
+// Package handler는 API Gateway 프록시 이벤트를 처리하는 HolyBean 핸들러 모음입니다.
+// 각 Lambda의 main.go는 저장소를 만들어 여기 있는 핸들러 하나를 lambda.Start에 넘깁니다.
+package handler
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/SIKU-KR/HolyBean/_legacy/aws-go/holybean"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// Func는 lambda.Start에 그대로 넘길 수 있는 핸들러 시그니처입니다.
+type Func func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+
+// header는 요청 헤더 값을 대소문자 구분 없이 찾습니다.
+// API Gateway는 클라이언트가 보낸 대소문자를 그대로 전달하기 때문입니다.
+func header(request events.APIGatewayProxyRequest, name string) string {
+	if value, ok := request.Headers[name]; ok {
+		return value
+	}
+	for key, value := range request.Headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
+
+// fail은 요청 ID를 담은 code의 오류 응답을 만듭니다.
+func fail(request events.APIGatewayProxyRequest, code holybean.ErrorCode, details ...holybean.FieldError) (events.APIGatewayProxyResponse, error) {
+	return holybean.Error(request.RequestContext.RequestID, code, details...)
+}
+
+// internalError는 err를 요청 ID와 함께 로그에 남기고 INTERNAL_ERROR 응답을 만듭니다.
+// 내부 오류 내용은 응답에 싣지 않으므로, 원인은 requestId로 로그에서 찾습니다.
+func internalError(request events.APIGatewayProxyRequest, action string, err error) (events.APIGatewayProxyResponse, error) {
+	log.Printf("[%s] %s: %v", request.RequestContext.RequestID, action, err)
+	return fail(request, holybean.CodeInternal)
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/SIKU-KR/HolyBean/_legacy/aws-go/holybean"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// IdempotencyKeyHeader는 재시도해도 주문이 한 번만 저장되도록 클라이언트가 붙이는 요청 헤더입니다.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// maxOrderNumAttempts는 서버가 정한 번호가 다른 주문과 겹칠 때 새 번호로 다시 저장해 보는 최대 횟수입니다.
+const maxOrderNumAttempts = 3
+
+type postOrderResponse struct {
+	Message   string `json:"message"`
+	OrderDate string `json:"orderDate"`
+	OrderNum  int    `json:"orderNum"`
+}
+
+type orderConflictResponse struct {
+	holybean.ErrorResponse
+	ConflictingOrder holybean.Order `json:"conflictingOrder"`
+}
+
+// PostOrder는 오늘 영업일(holybean.Calendar 기준)로 새 주문을 저장합니다.
+// 소계·총액·결제 금액이 서로 맞지 않으면 422(VALIDATION_FAILED)와 어긋난 필드 목록을 details로 반환합니다.
+// 같은 번호의 주문이 이미 있으면 덮어쓰지 않고 409와 기존 주문을 반환하고,
+// 이미 처리된 Idempotency-Key로 다시 요청하면 처음 저장한 결과를 그대로 반환합니다.
+// 본문에 orderNum이 없으면 서버가 오늘의 주문 번호 카운터에서 번호를 정합니다.
+func PostOrder(orders holybean.OrderRepository) Func {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		log.Printf("수신된 이벤트: %s", request.Body)
+
+		// 1. 요청 본문 파싱
+		var body holybean.OrderRequest
+		if err := json.Unmarshal([]byte(request.Body), &body); err != nil {
+			log.Printf("요청 본문 파싱 오류: %v", err)
+			return fail(request, holybean.CodeInvalidBody)
+		}
+
+		// 2. 필수 필드 체크 (포인터가 nil인지 확인)
+		if !body.HasRequiredFields() {
+			log.Println("잘못된 요청: 하나 이상의 필수 필드가 null입니다")
+			return fail(request, holybean.CodeMissingField)
+		}
+
+		// 3. 금액 검증 (어긋난 필드를 모두 돌려줌)
+		if errs := body.Validate(); len(errs) > 0 {
+			log.Printf("주문 검증 실패: %+v", errs)
+			return fail(request, holybean.CodeValidationFailed, errs...)
+		}
+
+		// 4. 저장 (같은 키가 있으면 덮어쓰지 않음)
+		now := time.Now()
+		order := body.ToOrder(holybean.BusinessDate(now))
+		order.CreatedAt = holybean.FormatCreatedAt(now)
+		order.IdempotencyKey = header(request, IdempotencyKeyHeader)
+		var err error
+		if body.OrderNum != nil {
+			err = orders.Put(ctx, order)
+		} else {
+			err = putWithAllocatedNum(ctx, orders, &order)
+		}
+
+		var replay *holybean.IdempotentReplayError
+		var conflict *holybean.OrderConflictError
+		switch {
+		case errors.As(err, &replay):
+			log.Printf("이미 처리된 요청입니다 (Idempotency-Key=%s): %s #%d", order.IdempotencyKey, replay.Original.OrderDate, replay.Original.OrderNum)
+			response, err := holybean.JSONResponse(200, postOrderResponse{
+				Message:   "아이템이 성공적으로 삽입되었습니다",
+				OrderDate: replay.Original.OrderDate,
+				OrderNum:  replay.Original.OrderNum,
+			})
+			response.Headers["Idempotent-Replayed"] = "true"
+			return response, err
+		case errors.As(err, &conflict):
+			log.Printf("주문 번호 충돌: %v", err)
+			return holybean.JSONResponse(holybean.CodeOrderConflict.Status(), orderConflictResponse{
+				ErrorResponse: holybean.NewErrorResponse(request.RequestContext.RequestID, holybean.CodeOrderConflict, holybean.FieldError{
+					Field:   "orderNum",
+					Message: fmt.Sprintf("%s #%d 주문이 이미 있습니다", conflict.Existing.OrderDate, conflict.Existing.OrderNum),
+				}),
+				ConflictingOrder: conflict.Existing,
+			})
+		case err != nil:
+			return internalError(request, "아이템 삽입 오류", err)
+		}
+
+		log.Println("아이템이 성공적으로 삽입되었습니다.")
+		return holybean.JSONResponse(200, postOrderResponse{
+			Message:   "아이템이 성공적으로 삽입되었습니다",
+			OrderDate: order.OrderDate,
+			OrderNum:  order.OrderNum,
+		})
+	}
+}
+
+// putWithAllocatedNum은 카운터에서 받은 번호로 주문을 저장합니다.
+// 그 사이 다른 클라이언트가 같은 번호를 직접 써 버렸으면 새 번호로 다시 시도합니다.
+func putWithAllocatedNum(ctx context.Context, orders holybean.OrderRepository, order *holybean.Order) error {
+	var err error
+	for attempt := 0; attempt < maxOrderNumAttempts; attempt++ {
+		if order.OrderNum, err = holybean.AllocateOrderNum(ctx, orders, order.OrderDate); err != nil {
+			return err
+		}
+		err = orders.Put(ctx, *order)
+		var conflict *holybean.OrderConflictError
+		if !errors.As(err, &conflict) {
+			return err
+		}
+		log.Printf("배정한 주문 번호가 이미 쓰였습니다, 다시 배정합니다: %v", err)
+	}
+	return err
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/SIKU-KR/HolyBean/_legacy/aws-go/holybean"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+type restoreMenuVersionResponse struct {
+	Message      string `json:"message"`
+	Timestamp    string `json:"timestamp"`
+	RestoredFrom string `json:"restoredFrom"`
+}
+
+// RestoreMenuVersion은 경로 파라미터 {timestamp} 버전의 메뉴를 새 버전으로 다시 저장해 되돌립니다.
+// 기존 버전은 지우지 않으므로 되돌린 것도 다시 되돌릴 수 있습니다.
+func RestoreMenuVersion(menus holybean.MenuRepository) Func {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		timestamp := request.PathParameters["timestamp"]
+		if timestamp == "" {
+			return fail(request, holybean.CodeMissingField,
+				holybean.FieldError{Field: "timestamp", Message: "경로에 timestamp가 필요합니다"})
+		}
+
+		source, err := menus.Get(ctx, timestamp)
+		if errors.Is(err, holybean.ErrMenuNotFound) {
+			return fail(request, holybean.CodeMenuNotFound,
+				holybean.FieldError{Field: "timestamp", Message: timestamp + " 버전이 없습니다"})
+		}
+		if err != nil {
+			return internalError(request, "Error loading menu version", err)
+		}
+
+		restored := holybean.Menu{
+			Timestamp:    holybean.NewMenuTimestamp(),
+			Entries:      source.Entries,
+			RestoredFrom: source.Timestamp,
+		}
+		if err := menus.Save(ctx, restored); err != nil {
+			return internalError(request, "Error saving restored menu", err)
+		}
+		log.Printf("Restored menu version %s as %s", source.Timestamp, restored.Timestamp)
+
+		return holybean.JSONResponse(200, restoreMenuVersionResponse{
+			Message:      "메뉴를 되돌렸습니다",
+			Timestamp:    restored.Timestamp,
+			RestoredFrom: restored.RestoredFrom,
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/SIKU-KR/HolyBean/_legacy/aws-go/holybean"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+type restoreOrderResponse struct {
+	Message      string         `json:"message"`
+	RestoredItem holybean.Order `json:"restoredItem"`
+}
+
+// RestoreOrder는 쿼리 파라미터 orderDate, orderNum으로 지정한 취소된 주문을 되살립니다.
+// 되살린 주문은 취소 전과 같이 리포트·하루 주문 목록·외상 목록에 다시 들어갑니다.
+func RestoreOrder(orders holybean.OrderRepository) Func {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		orderDate, orderNum, perr := orderKeyParams(request.QueryStringParameters)
+		if perr != nil {
+			return fail(request, perr.code, perr.detail)
+		}
+
+		restored, err := orders.Restore(ctx, orderDate, orderNum)
+		switch {
+		case errors.Is(err, holybean.ErrOrderNotFound):
+			return fail(request, holybean.CodeOrderNotFound)
+		case errors.Is(err, holybean.ErrNotVoided):
+			return fail(request, holybean.CodeInvalidState,
+				holybean.FieldError{Field: "orderNum", Message: "취소되지 않은 주문입니다"})
+		case err != nil:
+			return internalError(request, "주문 복구 중 오류 발생", err)
+		}
+
+		return holybean.JSONResponse(200, restoreOrderResponse{
+			Message:      "주문이 복구되었습니다.",
+			RestoredItem: restored,
+		})
+	}
+}
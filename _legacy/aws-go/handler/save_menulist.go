@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"context"
+	"log"
+
+	"github.com/SIKU-KR/HolyBean/_legacy/aws-go/holybean"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+type saveMenuListResponse struct {
+	Message   string `json:"message"`
+	Timestamp string `json:"timestamp"`
+}
+
+// SaveMenuList는 요청 본문의 메뉴 목록을 검증해 새 메뉴 버전으로 저장합니다.
+// 저장한 버전은 바로 GET /menu(get_last_menulist)의 응답이 됩니다.
+func SaveMenuList(menus holybean.MenuRepository) Func {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		body, err := holybean.DecodeMenuRequest([]byte(request.Body))
+		if err != nil {
+			log.Printf("Error parsing menu body: %v", err)
+			return fail(request, holybean.CodeInvalidBody, holybean.FieldError{Message: err.Error()})
+		}
+		if errs := body.Validate(); len(errs) > 0 {
+			log.Printf("Menu validation failed: %+v", errs)
+			return fail(request, holybean.CodeValidationFailed, errs...)
+		}
+
+		version := holybean.Menu{
+			Timestamp: holybean.NewMenuTimestamp(),
+			Entries:   body.Entries(),
+		}
+		if err := menus.Save(ctx, version); err != nil {
+			return internalError(request, "Error saving menu", err)
+		}
+		log.Printf("Saved menu version %s with %d items", version.Timestamp, len(version.Entries))
+
+		return holybean.JSONResponse(200, saveMenuListResponse{
+			Message:   "메뉴가 저장되었습니다",
+			Timestamp: version.Timestamp,
+		})
+	}
+}
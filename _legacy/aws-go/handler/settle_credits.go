@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/SIKU-KR/HolyBean/_legacy/aws-go/holybean"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// settleCreditsRequest는 settle_credits 요청 본문입니다. orders와 customer 중 하나만 줍니다.
+type settleCreditsRequest struct {
+	Orders   []holybean.OrderID `json:"orders"`
+	Customer string             `json:"customer"`
+	Method   string             `json:"method"`
+}
+
+type settleCreditsResponse struct {
+	Results      []holybean.SettleOutcome `json:"results"`
+	SettledCount int                      `json:"settledCount"`
+	TotalSettled holybean.Won             `json:"totalSettled"`
+	// Remaining은 customer로 정산하다 앞 묶음을 정산한 뒤 실패해 정산하지 못한 주문입니다.
+	// 같은 customer로 다시 요청하면 남은 주문만 정산됩니다.
+	Remaining []holybean.OrderID `json:"remaining,omitempty"`
+}
+
+// SettleCredits는 여러 외상 주문의 남은 금액을 한 번에 정산합니다.
+// 본문에 주문 키 목록(orders)을 주거나, 손님 이름(customer)을 주면 그 손님의 미정산 외상 전부를 정산합니다.
+// orders는 holybean.MaxBulkSettle건까지이며 한 트랜잭션으로 함께 성공하거나 함께 실패합니다.
+// customer는 건수 제한이 없습니다. MaxBulkSettle건씩 묶어 묶음마다 한 트랜잭션으로 정산하고,
+// 앞 묶음을 정산한 뒤 실패하면 거기까지의 결과와 정산하지 못한 주문(remaining)을 반환합니다.
+// 주문별 결과와 이번에 받은 총액을 반환합니다.
+// 받은 돈은 오늘 영업일 매출에 method(기본: 외상) 결제 수단으로 잡힙니다.
+func SettleCredits(orders holybean.OrderRepository) Func {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		var body settleCreditsRequest
+		if err := json.Unmarshal([]byte(request.Body), &body); err != nil {
+			return fail(request, holybean.CodeInvalidBody)
+		}
+		customer := holybean.CustomerKey(body.Customer)
+		if (len(body.Orders) == 0) == (customer == "") {
+			return fail(request, holybean.CodeMissingField,
+				holybean.FieldError{Field: "orders", Message: "orders와 customer 중 하나만 있어야 합니다"})
+		}
+
+		var errs []holybean.FieldError
+		for i, id := range body.Orders {
+			if _, err := time.Parse(holybean.DateLayout, id.OrderDate); err != nil || id.OrderNum <= 0 {
+				errs = append(errs, holybean.FieldError{
+					Field:   fmt.Sprintf("orders[%d]", i),
+					Message: "orderDate(YYYY-MM-DD)와 양수 orderNum이 필요합니다",
+				})
+			}
+		}
+		if len(errs) > 0 {
+			return fail(request, holybean.CodeValidationFailed, errs...)
+		}
+
+		ids := body.Orders
+		if customer != "" {
+			credits, err := orders.ListOpenCredits(ctx)
+			if err != nil {
+				return internalError(request, "Error listing open credits", err)
+			}
+			for _, order := range credits {
+				if holybean.CustomerKey(order.CustomerName) == customer {
+					ids = append(ids, holybean.OrderID{OrderDate: order.OrderDate, OrderNum: order.OrderNum})
+				}
+			}
+		}
+
+		now := time.Now()
+		payment := holybean.CreditPayment{
+			Method:   strings.TrimSpace(body.Method),
+			Date:     holybean.BusinessDate(now),
+			PaidAt:   holybean.FormatCreatedAt(now),
+			Operator: strings.TrimSpace(header(request, OperatorHeader)),
+		}
+		if customer != "" {
+			return settleInChunks(ctx, orders, request, ids, payment)
+		}
+		outcomes, err := orders.SettleCredits(ctx, ids, payment)
+		if errors.Is(err, holybean.ErrTooManyOrders) {
+			return fail(request, holybean.CodeValidationFailed, holybean.FieldError{
+				Field:   "orders",
+				Message: fmt.Sprintf("한 번에 %d건까지 정산할 수 있습니다 (요청 %d건)", holybean.MaxBulkSettle, len(ids)),
+			})
+		}
+		if err != nil {
+			return internalError(request, "Error settling credits", err)
+		}
+
+		return holybean.JSONResponse(200, newSettleCreditsResponse(outcomes, nil))
+	}
+}
+
+// settleInChunks는 ids를 holybean.MaxBulkSettle건씩 나눠 차례로 정산합니다.
+// 첫 묶음이 실패하면 아무것도 바뀌지 않았으므로 오류를, 그 뒤 묶음이 실패하면 정산한 결과와 남은 주문을 반환합니다.
+func settleInChunks(ctx context.Context, orders holybean.OrderRepository, request events.APIGatewayProxyRequest, ids []holybean.OrderID, payment holybean.CreditPayment) (events.APIGatewayProxyResponse, error) {
+	var outcomes []holybean.SettleOutcome
+	for start := 0; start < len(ids); start += holybean.MaxBulkSettle {
+		end := start + holybean.MaxBulkSettle
+		if end > len(ids) {
+			end = len(ids)
+		}
+		chunk, err := orders.SettleCredits(ctx, ids[start:end], payment)
+		if err != nil && start == 0 {
+			return internalError(request, "Error settling credits", err)
+		}
+		if err != nil {
+			log.Printf("외상 일괄 정산 %d번째 주문부터 실패: %v", start, err)
+			return holybean.JSONResponse(200, newSettleCreditsResponse(outcomes, ids[start:]))
+		}
+		outcomes = append(outcomes, chunk...)
+	}
+	return holybean.JSONResponse(200, newSettleCreditsResponse(outcomes, nil))
+}
+
+func newSettleCreditsResponse(outcomes []holybean.SettleOutcome, remaining []holybean.OrderID) settleCreditsResponse {
+	response := settleCreditsResponse{Results: outcomes, Remaining: remaining}
+	if response.Results == nil {
+		response.Results = []holybean.SettleOutcome{}
+	}
+	for _, outcome := range outcomes {
+		if outcome.Status == holybean.SettleSettled {
+			response.SettledCount++
+			response.TotalSettled += outcome.Amount
+		}
+	}
+	return response
+}
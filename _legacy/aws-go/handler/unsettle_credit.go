@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/SIKU-KR/HolyBean/_legacy/aws-go/holybean"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// unsettleCreditRequest는 unsettle_credit 요청 본문입니다. 본문은 없어도 됩니다.
+type unsettleCreditRequest struct {
+	Reason string `json:"reason"`
+}
+
+// UnsettleCredit은 경로 파라미터 {orderDate}/{number}의 정산된 외상 주문을 다시 미정산으로 되돌립니다.
+// 잘못 누른 정산을 취소하는 용도로, 정산하며 받은 돈은 그날 매출에서 빠지고 남은 외상이 그만큼 늘어납니다.
+// 취소한 시각, X-Operator 헤더의 직원, 본문의 reason은 주문의 creditHistory에 남습니다.
+// 외상 주문이 아니거나 정산되지 않은 주문이면 INVALID_STATE입니다.
+func UnsettleCredit(orders holybean.OrderRepository) Func {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		orderDate, orderNum, perr := creditOrderKey(request)
+		if perr != nil {
+			return fail(request, perr.code, perr.detail)
+		}
+		var body unsettleCreditRequest
+		if strings.TrimSpace(request.Body) != "" {
+			if err := json.Unmarshal([]byte(request.Body), &body); err != nil {
+				return fail(request, holybean.CodeInvalidBody)
+			}
+		}
+
+		order, err := orders.UnsettleCredit(ctx, orderDate, orderNum, holybean.CreditEvent{
+			At:       holybean.FormatCreatedAt(time.Now()),
+			Operator: strings.TrimSpace(header(request, OperatorHeader)),
+			Reason:   strings.TrimSpace(body.Reason),
+		})
+		switch {
+		case errors.Is(err, holybean.ErrOrderNotFound):
+			return fail(request, holybean.CodeOrderNotFound)
+		case errors.Is(err, holybean.ErrOrderVoided):
+			return fail(request, holybean.CodeInvalidState,
+				holybean.FieldError{Field: "orderNum", Message: "취소된 주문입니다"})
+		case errors.Is(err, holybean.ErrNotCredit):
+			return fail(request, holybean.CodeInvalidState,
+				holybean.FieldError{Field: "orderNum", Message: "외상 주문이 아닙니다"})
+		case errors.Is(err, holybean.ErrNotSettled):
+			return fail(request, holybean.CodeInvalidState,
+				holybean.FieldError{Field: "creditStatus", Message: "정산되지 않은 외상은 취소할 수 없습니다"})
+		case err != nil:
+			return internalError(request, "Error unsettling credit", err)
+		}
+		return holybean.JSONResponse(200, newCreditResponse("Order credit status updated to 1", order))
+	}
+}
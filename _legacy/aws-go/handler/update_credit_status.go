@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SIKU-KR/HolyBean/_legacy/aws-go/holybean"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// OperatorHeader는 외상 입금·정산·정산 취소를 한 직원을 알리는 요청 헤더입니다. 없어도 처리하지만 이력에 이름이 남지 않습니다.
+const OperatorHeader = "X-Operator"
+
+// creditPaymentRequest는 update_credit_status 요청 본문입니다. 본문이 없으면 남은 금액 전부를 정산합니다.
+type creditPaymentRequest struct {
+	Amount *holybean.Won `json:"amount"`
+	Method string        `json:"method"`
+}
+
+type updateCreditStatusResponse struct {
+	Message           string                   `json:"message"`
+	UpdatedAttributes creditStatusAttribute    `json:"updatedAttributes"`
+	TotalAmount       holybean.Won             `json:"totalAmount"`
+	Paid              holybean.Won             `json:"paid"`
+	Balance           holybean.Won             `json:"balance"`
+	CreditPayments    []holybean.CreditPayment `json:"creditPayments"`
+	CreditHistory     []holybean.CreditEvent   `json:"creditHistory"`
+}
+
+// newCreditResponse는 외상 주문의 현재 금액·장부·이력을 담은 응답 본문을 만듭니다.
+func newCreditResponse(message string, order holybean.Order) updateCreditStatusResponse {
+	response := updateCreditStatusResponse{
+		Message:           message,
+		UpdatedAttributes: creditStatusAttribute{CreditStatus: order.CreditStatus},
+		TotalAmount:       order.TotalAmount,
+		Paid:              order.Paid(),
+		Balance:           order.Balance(),
+		CreditPayments:    order.CreditPayments,
+		CreditHistory:     order.CreditHistory,
+	}
+	if response.CreditHistory == nil {
+		response.CreditHistory = []holybean.CreditEvent{}
+	}
+	return response
+}
+
+// creditOrderKey는 경로 파라미터 {orderDate}/{number}를 주문 키로 읽습니다.
+func creditOrderKey(request events.APIGatewayProxyRequest) (string, int, *paramError) {
+	orderNumStr := request.PathParameters["number"]
+	orderDate := request.PathParameters["orderDate"]
+	if orderNumStr == "" || orderDate == "" {
+		return "", 0, &paramError{holybean.CodeMissingField,
+			holybean.FieldError{Field: "orderDate", Message: "경로에 orderDate와 number가 모두 필요합니다"}}
+	}
+	orderNum, err := strconv.Atoi(orderNumStr)
+	if err != nil {
+		return "", 0, &paramError{holybean.CodeInvalidParameter,
+			holybean.FieldError{Field: "number", Message: "number는 정수여야 합니다: " + orderNumStr}}
+	}
+	return orderDate, orderNum, nil
+}
+
+type creditStatusAttribute struct {
+	CreditStatus holybean.CreditStatus `json:"creditStatus"`
+}
+
+// UpdateCreditStatus는 경로 파라미터 {orderDate}/{number} 외상 주문에 입금을 기록합니다.
+// 본문의 amount만큼 갚고, 본문이 없거나 amount를 생략하면 남은 금액 전부를 정산합니다.
+// 남은 금액이 0이 되면 creditStatus가 0이 됩니다. 받은 돈은 오늘 영업일 매출에 잡힙니다.
+// 기록한 시각, X-Operator 헤더의 직원, 결제 수단은 주문의 creditHistory에 남습니다.
+func UpdateCreditStatus(orders holybean.OrderRepository) Func {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		orderDate, orderNum, perr := creditOrderKey(request)
+		if perr != nil {
+			return fail(request, perr.code, perr.detail)
+		}
+
+		var body creditPaymentRequest
+		if strings.TrimSpace(request.Body) != "" {
+			if err := json.Unmarshal([]byte(request.Body), &body); err != nil {
+				return fail(request, holybean.CodeInvalidBody)
+			}
+		}
+		now := time.Now()
+		payment := holybean.CreditPayment{
+			Method:   strings.TrimSpace(body.Method),
+			Date:     holybean.BusinessDate(now),
+			PaidAt:   holybean.FormatCreatedAt(now),
+			Operator: strings.TrimSpace(header(request, OperatorHeader)),
+		}
+		if body.Amount != nil {
+			if *body.Amount <= 0 {
+				return fail(request, holybean.CodeValidationFailed,
+					holybean.FieldError{Field: "amount", Message: fmt.Sprintf("입금액은 양수여야 합니다: %d", *body.Amount)})
+			}
+			payment.Amount = *body.Amount
+		}
+
+		order, err := orders.PayCredit(ctx, orderDate, orderNum, payment)
+		var overpayment *holybean.OverpaymentError
+		switch {
+		case errors.Is(err, holybean.ErrOrderNotFound):
+			return fail(request, holybean.CodeOrderNotFound)
+		case errors.Is(err, holybean.ErrOrderVoided):
+			return fail(request, holybean.CodeInvalidState,
+				holybean.FieldError{Field: "orderNum", Message: "취소된 주문입니다"})
+		case errors.As(err, &overpayment):
+			return fail(request, holybean.CodeValidationFailed, holybean.FieldError{
+				Field:   "amount",
+				Message: fmt.Sprintf("입금액 %d원이 남은 외상 %d원보다 많습니다", overpayment.Amount, overpayment.Balance),
+			})
+		case err != nil:
+			return internalError(request, "Error updating order", err)
+		}
+
+		message := "Order credit status updated to 0"
+		if order.CreditStatus != holybean.CreditSettled {
+			message = "Credit payment recorded"
+		}
+		return holybean.JSONResponse(200, newCreditResponse(message, order))
+	}
+}
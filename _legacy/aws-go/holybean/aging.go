@@ -0,0 +1,84 @@
+package holybean
+
+import "time"
+
+// AgingBucket은 외상 연령(orderDate부터 지난 날 수) 구간 하나의 합계입니다.
+// MaxDays가 0이면 위쪽 끝이 없는 마지막 구간입니다.
+type AgingBucket struct {
+	Label       string `json:"label"`
+	MinDays     int    `json:"minDays"`
+	MaxDays     int    `json:"maxDays,omitempty"`
+	Count       int    `json:"count"`
+	Outstanding Won    `json:"outstanding"`
+}
+
+// newAgingBuckets는 0–7일, 8–30일, 31–90일, 90일 초과 구간을 빈 합계로 만듭니다.
+func newAgingBuckets() []AgingBucket {
+	return []AgingBucket{
+		{Label: "0-7", MinDays: 0, MaxDays: 7},
+		{Label: "8-30", MinDays: 8, MaxDays: 30},
+		{Label: "31-90", MinDays: 31, MaxDays: 90},
+		{Label: "90+", MinDays: 91},
+	}
+}
+
+// addToAgingBucket은 age일 된 외상 amount를 알맞은 구간에 더합니다. 미래 날짜(음수)는 첫 구간에 넣습니다.
+func addToAgingBucket(buckets []AgingBucket, age int, amount Won) {
+	i := 0
+	for i < len(buckets)-1 && age > buckets[i].MaxDays {
+		i++
+	}
+	buckets[i].Count++
+	buckets[i].Outstanding += amount
+}
+
+// CustomerAging은 한 손님의 외상을 연령 구간별로 나눈 것입니다.
+type CustomerAging struct {
+	CustomerKey      string        `json:"customerKey"`
+	CustomerName     string        `json:"customerName"`
+	Outstanding      Won           `json:"outstanding"`
+	OrderCount       int           `json:"orderCount"`
+	OldestUnpaidDate string        `json:"oldestUnpaidDate"`
+	Buckets          []AgingBucket `json:"buckets"`
+}
+
+// AgingReport는 asOf 날짜 기준 미정산 외상의 연령 구간별 합계와 손님별 내역입니다.
+type AgingReport struct {
+	AsOf        string          `json:"asOf"`
+	Outstanding Won             `json:"outstanding"`
+	OrderCount  int             `json:"orderCount"`
+	Buckets     []AgingBucket   `json:"buckets"`
+	Customers   []CustomerAging `json:"customers"`
+}
+
+// AgeCredits는 미정산 외상 주문의 남은 금액을 asOf(YYYY-MM-DD) 기준 연령 구간으로 나눕니다.
+// orders는 ListOpenCredits 결과여야 하며, 손님 순서는 GroupCredits와 같이 남은 금액이 큰 손님부터입니다.
+func AgeCredits(orders []Order, asOf time.Time) AgingReport {
+	report := AgingReport{
+		AsOf:      asOf.Format(DateLayout),
+		Buckets:   newAgingBuckets(),
+		Customers: []CustomerAging{},
+	}
+	for _, account := range GroupCredits(orders) {
+		customer := CustomerAging{
+			CustomerKey:      account.CustomerKey,
+			CustomerName:     account.CustomerName,
+			Outstanding:      account.Outstanding,
+			OrderCount:       account.OrderCount,
+			OldestUnpaidDate: account.OldestUnpaidDate,
+			Buckets:          newAgingBuckets(),
+		}
+		for _, order := range account.Orders {
+			age := 0
+			if orderDate, err := time.Parse(DateLayout, order.OrderDate); err == nil {
+				age = int(asOf.Sub(orderDate).Hours() / 24)
+			}
+			addToAgingBucket(customer.Buckets, age, order.Balance())
+			addToAgingBucket(report.Buckets, age, order.Balance())
+		}
+		report.Outstanding += customer.Outstanding
+		report.OrderCount += customer.OrderCount
+		report.Customers = append(report.Customers, customer)
+	}
+	return report
+}
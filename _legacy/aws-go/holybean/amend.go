@@ -0,0 +1,98 @@
+package holybean
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrCreditRecorded는 외상 입금 기록이 있는 주문을 고치려 할 때 반환됩니다.
+// 고친 총액이 장부와 맞지 않게 될 수 있으므로 이런 주문은 고칠 수 없습니다.
+var ErrCreditRecorded = errors.New("order has credit payments")
+
+// VersionConflictError는 읽은 뒤 다른 요청이 주문을 먼저 고쳐 Version이 달라졌을 때 반환됩니다.
+// Current는 지금 저장된 주문입니다.
+type VersionConflictError struct {
+	Expected int
+	Current  Order
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("order %s #%d is at version %d, not %d", e.Current.OrderDate, e.Current.OrderNum, e.Current.Version, e.Expected)
+}
+
+// OrderRevision은 고치기 전 주문 내용 하나입니다. AmendedAt, AmendedBy는 이 내용을 바꾼 수정의 시각과 직원입니다.
+type OrderRevision struct {
+	Version        int             `json:"version" dynamodbav:"version"`
+	TotalAmount    Won             `json:"totalAmount" dynamodbav:"totalAmount"`
+	CustomerName   string          `json:"customerName" dynamodbav:"customerName,omitempty"`
+	PaymentMethods []PaymentMethod `json:"paymentMethods" dynamodbav:"paymentMethods"`
+	OrderItems     []OrderItem     `json:"orderItems" dynamodbav:"orderItems"`
+	CreditStatus   CreditStatus    `json:"creditStatus" dynamodbav:"creditStatus"`
+	AmendedAt      string          `json:"amendedAt" dynamodbav:"amendedAt"`
+	AmendedBy      string          `json:"amendedBy,omitempty" dynamodbav:"amendedBy,omitempty"`
+}
+
+// OrderAmendment는 주문 수정 요청입니다. 주문 내용 필드와 CreditStatus는 통째로 바뀝니다.
+type OrderAmendment struct {
+	TotalAmount    Won
+	CustomerName   string
+	PaymentMethods []PaymentMethod
+	OrderItems     []OrderItem
+	CreditStatus   CreditStatus
+	// Version은 고치려는 쪽이 읽은 주문의 Version입니다.
+	Version int
+	// At, Operator는 수정한 시각(Asia/Seoul, RFC 3339)과 직원입니다.
+	At       string
+	Operator string
+	// Date는 At의 영업일(YYYY-MM-DD)입니다. 외상을 정산으로 바꾸면 이 날 받은 것으로 장부에 남습니다.
+	Date string
+}
+
+// Amend는 지금 내용을 Revisions에 남기고 amendment의 내용으로 바꾼 주문을 반환합니다. Version은 1 오릅니다.
+// 키, createdAt, 멱등성 키는 그대로입니다. amendment의 금액 검증은 호출한 쪽이 OrderRequest.Validate로 합니다.
+// creditStatus를 바꾸면 고친 내용에 정산·정산 취소와 같은 장부 처리를 합니다.
+//   - 1 → 0: 남은 외상 전액을 amendment.Date에 받은 입금으로 기록합니다(ApplyPayment). 외상분과 메뉴 판매는 그날 집계로 옮겨 갑니다.
+//   - 0 → 1: 정산을 취소합니다(Unsettle). 고친 내용이 외상 주문이 아니면 ErrNotCredit을 반환합니다.
+//
+// 어느 쪽이든 외상 이력(CreditHistory)에 남습니다.
+// Version이 다르면 *VersionConflictError를, 취소된 주문이면 ErrOrderVoided를, 외상 입금 기록이 있으면 ErrCreditRecorded를 반환합니다.
+// 입금 기록이 있으면 고친 총액을 기존 장부와 맞출 수 없으므로 creditStatus를 바꾸든 말든 고칠 수 없습니다.
+func (o Order) Amend(amendment OrderAmendment) (Order, error) {
+	if o.Version != amendment.Version {
+		return Order{}, &VersionConflictError{Expected: amendment.Version, Current: o}
+	}
+	if o.IsVoided() {
+		return Order{}, ErrOrderVoided
+	}
+	if len(o.CreditPayments) > 0 {
+		return Order{}, ErrCreditRecorded
+	}
+	amended := o.clone()
+	amended.Revisions = append(amended.Revisions, OrderRevision{
+		Version:        o.Version,
+		TotalAmount:    o.TotalAmount,
+		CustomerName:   o.CustomerName,
+		PaymentMethods: o.PaymentMethods,
+		OrderItems:     o.OrderItems,
+		CreditStatus:   o.CreditStatus,
+		AmendedAt:      amendment.At,
+		AmendedBy:      amendment.Operator,
+	})
+	amended.TotalAmount = amendment.TotalAmount
+	amended.CustomerName = amendment.CustomerName
+	amended.PaymentMethods = append([]PaymentMethod(nil), amendment.PaymentMethods...)
+	amended.OrderItems = append([]OrderItem(nil), amendment.OrderItems...)
+	amended.Version++
+
+	switch {
+	case amendment.CreditStatus == o.CreditStatus:
+		return amended, nil
+	case amendment.CreditStatus == CreditSettled:
+		return amended.ApplyPayment(CreditPayment{Date: amendment.Date, PaidAt: amendment.At, Operator: amendment.Operator})
+	default:
+		return amended.Unsettle(CreditEvent{At: amendment.At, Operator: amendment.Operator, Reason: amendReason})
+	}
+}
+
+// amendReason은 수정으로 정산을 취소했을 때 외상 이력에 남는 사유입니다.
+const amendReason = "주문 수정"
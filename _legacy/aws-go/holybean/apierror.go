@@ -0,0 +1,89 @@
+package holybean
+
+import (
+	"log"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// ErrorCode는 오류 응답의 기계 판독용 코드입니다. 앱은 메시지 대신 이 코드로 분기합니다.
+// 한 번 배포한 코드의 이름과 의미는 바꾸지 않습니다.
+type ErrorCode string
+
+const (
+	CodeInvalidBody      ErrorCode = "INVALID_BODY"
+	CodeMissingField     ErrorCode = "MISSING_FIELD"
+	CodeInvalidParameter ErrorCode = "INVALID_PARAMETER"
+	CodeValidationFailed ErrorCode = "VALIDATION_FAILED"
+	CodeForbidden        ErrorCode = "FORBIDDEN"
+	CodeOrderNotFound    ErrorCode = "ORDER_NOT_FOUND"
+	CodeMenuNotFound     ErrorCode = "MENU_NOT_FOUND"
+	CodeOrderConflict    ErrorCode = "ORDER_CONFLICT"
+	CodeInvalidState     ErrorCode = "INVALID_STATE"
+	CodeVersionConflict  ErrorCode = "VERSION_CONFLICT"
+	CodeInternal         ErrorCode = "INTERNAL_ERROR"
+)
+
+type errorCatalogEntry struct {
+	status    int
+	message   string
+	messageEn string
+}
+
+// errorCatalog는 코드별 HTTP 상태와 기본 메시지입니다.
+// 상황별 설명은 메시지를 바꾸지 말고 details에 담습니다.
+var errorCatalog = map[ErrorCode]errorCatalogEntry{
+	CodeInvalidBody:      {400, "요청 본문이 잘못되었거나 비어 있습니다", "Request body is malformed or missing"},
+	CodeMissingField:     {400, "필수 값이 누락되었습니다", "Required fields are missing"},
+	CodeInvalidParameter: {400, "요청 값의 형식이 올바르지 않습니다", "Request parameters are malformed"},
+	CodeValidationFailed: {422, "요청 값 검증에 실패했습니다", "Request validation failed"},
+	CodeForbidden:        {403, "접근 권한이 없습니다", "Forbidden"},
+	CodeOrderNotFound:    {404, "주문을 찾을 수 없습니다", "Order not found"},
+	CodeMenuNotFound:     {404, "메뉴 버전을 찾을 수 없습니다", "Menu version not found"},
+	CodeOrderConflict:    {409, "같은 번호의 주문이 이미 있습니다", "An order with the same number already exists"},
+	CodeInvalidState:     {409, "주문의 현재 상태에서는 할 수 없는 작업입니다", "The operation is not allowed in the order's current state"},
+	CodeVersionConflict:  {409, "다른 곳에서 주문이 먼저 수정되었습니다", "The order was modified by another request"},
+	CodeInternal:         {500, "서버 오류가 발생했습니다", "Internal server error"},
+}
+
+// Status는 코드에 해당하는 HTTP 상태 코드를 반환합니다. 목록에 없는 코드는 500입니다.
+func (c ErrorCode) Status() int {
+	if entry, ok := errorCatalog[c]; ok {
+		return entry.status
+	}
+	return 500
+}
+
+// ErrorResponse는 모든 실패 응답이 공유하는 본문입니다.
+type ErrorResponse struct {
+	Code      ErrorCode    `json:"code"`
+	Message   string       `json:"message"`
+	MessageEn string       `json:"messageEn"`
+	Details   []FieldError `json:"details"`
+	RequestID string       `json:"requestId"`
+}
+
+// NewErrorResponse는 code의 기본 메시지로 오류 본문을 만듭니다.
+// 본문에 필드를 더해야 하는 핸들러는 이 값을 임베드해 씁니다.
+func NewErrorResponse(requestID string, code ErrorCode, details ...FieldError) ErrorResponse {
+	entry, ok := errorCatalog[code]
+	if !ok {
+		log.Printf("unknown error code %q", code)
+		code, entry = CodeInternal, errorCatalog[CodeInternal]
+	}
+	if details == nil {
+		details = []FieldError{}
+	}
+	return ErrorResponse{
+		Code:      code,
+		Message:   entry.message,
+		MessageEn: entry.messageEn,
+		Details:   details,
+		RequestID: requestID,
+	}
+}
+
+// Error는 code에 맞는 상태 코드와 오류 본문으로 응답을 만듭니다.
+func Error(requestID string, code ErrorCode, details ...FieldError) (events.APIGatewayProxyResponse, error) {
+	return JSONResponse(code.Status(), NewErrorResponse(requestID, code, details...))
+}
@@ -0,0 +1,110 @@
+package holybean
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	// provided.al2 런타임에는 시간대 데이터(zoneinfo)가 없으므로 실행 파일에 넣어 HOLYBEAN_TIME_ZONE을 불러올 수 있게 합니다.
+	_ "time/tzdata"
+)
+
+// DateLayout은 orderDate와 리포트 기간에 쓰는 날짜 형식입니다.
+const DateLayout = "2006-01-02"
+
+// 영업일 규칙을 정하는 환경 변수입니다. 없으면 Asia/Seoul 자정 기준입니다.
+const (
+	// TimeZoneEnv는 영업일을 정하는 시간대(IANA 이름, 예: Asia/Seoul)입니다.
+	TimeZoneEnv = "HOLYBEAN_TIME_ZONE"
+	// DayRolloverHourEnv는 영업일이 바뀌는 시(0~23)입니다.
+	DayRolloverHourEnv = "HOLYBEAN_DAY_ROLLOVER_HOUR"
+)
+
+// Seoul은 카페가 있는 Asia/Seoul 시간대입니다.
+// 시간대 데이터를 불러오지 못하면 같은 오프셋(UTC+9, 서머타임 없음)의 고정 시간대를 씁니다.
+var Seoul = loadSeoul()
+
+func loadSeoul() *time.Location {
+	location, err := time.LoadLocation("Asia/Seoul")
+	if err != nil {
+		log.Printf("Asia/Seoul 시간대를 불러오지 못해 UTC+9 고정 시간대를 씁니다: %v", err)
+		return time.FixedZone("KST", 9*60*60)
+	}
+	return location
+}
+
+// BusinessCalendar는 시각을 영업일(orderDate)로 바꾸는 규칙입니다.
+// Lambda 시계는 UTC이므로 날짜는 항상 이 규칙으로 정해야 합니다.
+type BusinessCalendar struct {
+	Location *time.Location
+	// RolloverHour는 영업일이 바뀌는 시(0~23)입니다. 4이면 새벽 4시 전 주문은 전날 영업일로 잡힙니다.
+	RolloverHour int
+}
+
+// Date는 t가 속한 영업일(YYYY-MM-DD)입니다.
+func (c BusinessCalendar) Date(t time.Time) string {
+	local := t.In(c.Location)
+	if local.Hour() < c.RolloverHour {
+		local = local.AddDate(0, 0, -1)
+	}
+	return local.Format(DateLayout)
+}
+
+// LoadBusinessCalendar는 환경 변수에서 영업일 규칙을 읽습니다.
+func LoadBusinessCalendar() (BusinessCalendar, error) {
+	calendar := BusinessCalendar{Location: Seoul}
+	if name := os.Getenv(TimeZoneEnv); name != "" {
+		location, err := time.LoadLocation(name)
+		if err != nil {
+			return BusinessCalendar{}, fmt.Errorf("%s: %w", TimeZoneEnv, err)
+		}
+		calendar.Location = location
+	}
+	if value := os.Getenv(DayRolloverHourEnv); value != "" {
+		hour, err := strconv.Atoi(value)
+		if err != nil || hour < 0 || hour > 23 {
+			return BusinessCalendar{}, fmt.Errorf("%s는 0~23 사이 정수여야 합니다: %q", DayRolloverHourEnv, value)
+		}
+		calendar.RolloverHour = hour
+	}
+	return calendar, nil
+}
+
+// Calendar는 핸들러와 관리 도구가 함께 쓰는 영업일 규칙입니다.
+// 환경 변수가 잘못되었으면 로그를 남기고 기본값(Asia/Seoul 자정)을 씁니다.
+var Calendar = defaultCalendar()
+
+func defaultCalendar() BusinessCalendar {
+	calendar, err := LoadBusinessCalendar()
+	if err != nil {
+		log.Printf("영업일 설정이 잘못되어 Asia/Seoul 자정 기준을 씁니다: %v", err)
+		return BusinessCalendar{Location: Seoul}
+	}
+	return calendar
+}
+
+// BusinessDate는 Calendar 기준으로 t가 속한 영업일입니다.
+func BusinessDate(t time.Time) string {
+	return Calendar.Date(t)
+}
+
+// Today는 Calendar 기준 오늘 영업일입니다.
+func Today() string {
+	return BusinessDate(time.Now())
+}
+
+// FormatCreatedAt은 t를 주문 createdAt 형식(Asia/Seoul 기준 RFC 3339)으로 만듭니다.
+func FormatCreatedAt(t time.Time) string {
+	return t.In(Seoul).Format(time.RFC3339)
+}
+
+// ParseCreatedAt은 주문 createdAt 값을 Asia/Seoul 시각으로 읽습니다.
+func ParseCreatedAt(value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.In(Seoul), nil
+}
@@ -0,0 +1,368 @@
+package holybean
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// CreditMethod는 외상 결제 수단 이름입니다. 앱의 결제 수단 목록과 같은 값입니다.
+// 받은 돈의 결제 수단을 모를 때(예: 예전 방식의 일괄 정산)도 이 이름으로 집계합니다.
+const CreditMethod = "외상"
+
+// CreditPayment는 외상 장부의 입금 한 건입니다.
+type CreditPayment struct {
+	Amount Won    `json:"amount" dynamodbav:"amount"`
+	Method string `json:"method" dynamodbav:"method"`
+	// Date는 돈을 받은 영업일(YYYY-MM-DD)입니다. 매출 리포트에서 이 날짜에 잡힙니다.
+	Date string `json:"date" dynamodbav:"date"`
+	// PaidAt은 입금을 기록한 시각(Asia/Seoul, RFC 3339)입니다.
+	PaidAt string `json:"paidAt" dynamodbav:"paidAt"`
+	// Operator는 입금을 기록한 직원입니다. 요청에 없으면 비어 있습니다.
+	Operator string `json:"operator,omitempty" dynamodbav:"operator,omitempty"`
+}
+
+// CreditEventType은 외상 이력 한 건의 종류입니다.
+type CreditEventType string
+
+const (
+	// CreditEventPayment는 남은 금액 일부를 받은 입금입니다.
+	CreditEventPayment CreditEventType = "payment"
+	// CreditEventSettle은 남은 금액을 모두 받아 정산한 입금입니다.
+	CreditEventSettle CreditEventType = "settle"
+	// CreditEventUnsettle은 정산을 취소해 외상으로 되돌린 것입니다.
+	CreditEventUnsettle CreditEventType = "unsettle"
+)
+
+// CreditEvent는 외상 주문의 상태 변화 한 건입니다. 이력은 덧붙이기만 하고 고치거나 지우지 않습니다.
+type CreditEvent struct {
+	Type CreditEventType `json:"type" dynamodbav:"type"`
+	// At은 변화를 기록한 시각(Asia/Seoul, RFC 3339)입니다.
+	At       string `json:"at" dynamodbav:"at"`
+	Operator string `json:"operator,omitempty" dynamodbav:"operator,omitempty"`
+	// Amount는 입금·정산에서 받은 금액, 정산 취소에서 되돌린 금액입니다.
+	Amount Won    `json:"amount" dynamodbav:"amount"`
+	Method string `json:"method,omitempty" dynamodbav:"method,omitempty"`
+	Reason string `json:"reason,omitempty" dynamodbav:"reason,omitempty"`
+}
+
+var (
+	// ErrNotCredit은 외상 주문이 아닌 주문의 정산을 취소하려 할 때 반환됩니다.
+	ErrNotCredit = errors.New("order is not a credit order")
+	// ErrNotSettled는 정산되지 않은 외상 주문의 정산을 취소하려 할 때 반환됩니다.
+	ErrNotSettled = errors.New("credit is not settled")
+)
+
+// OverpaymentError는 입금액이 남은 외상 금액보다 많을 때 반환됩니다.
+type OverpaymentError struct {
+	Balance Won
+	Amount  Won
+}
+
+func (e *OverpaymentError) Error() string {
+	return fmt.Sprintf("payment %d exceeds outstanding balance %d", e.Amount, e.Balance)
+}
+
+// Paid는 외상 장부에 기록된 입금액 합계입니다.
+func (o Order) Paid() Won {
+	var paid Won
+	for _, payment := range o.CreditPayments {
+		paid += payment.Amount
+	}
+	return paid
+}
+
+// CreditAmount는 주문 금액 중 외상으로 달아 둔 부분으로, 결제 수단 중 CreditMethod 금액의 합입니다.
+// 앱은 "현금 3000 + 외상 2000"처럼 나눠 낸 주문도 외상 주문으로 저장하므로, 총액이 아니라 이 금액만 받을 돈입니다.
+// 외상 결제 수단 없이 creditStatus만 1로 저장된 예전 주문은 총액 전체를 외상으로 봅니다.
+func (o Order) CreditAmount() Won {
+	var amount Won
+	found := false
+	for _, pm := range o.PaymentMethods {
+		if pm.Method == CreditMethod {
+			amount += pm.Amount
+			found = true
+		}
+	}
+	if !found {
+		return o.TotalAmount
+	}
+	return amount
+}
+
+// upfrontPayments는 주문할 때 바로 받은 결제(외상이 아닌 결제 수단)입니다.
+// 외상 결제 수단이 없는 주문은 CreditAmount와 같이 전체를 외상으로 보므로 비어 있습니다.
+func (o Order) upfrontPayments() []PaymentMethod {
+	var upfront []PaymentMethod
+	hasCredit := false
+	for _, pm := range o.PaymentMethods {
+		if pm.Method == CreditMethod {
+			hasCredit = true
+		} else {
+			upfront = append(upfront, pm)
+		}
+	}
+	if !hasCredit {
+		return nil
+	}
+	return upfront
+}
+
+// Balance는 남은 외상 금액입니다. 정산된 주문은 0입니다.
+// 외상으로 달아 둔 금액(CreditAmount)에서 장부에 기록된 입금액만큼 줄어듭니다.
+func (o Order) Balance() Won {
+	if o.CreditStatus == CreditSettled {
+		return 0
+	}
+	return o.CreditAmount() - o.Paid()
+}
+
+// ApplyPayment는 입금 한 건을 장부에 더한 주문을 반환합니다. 남은 금액이 0이 되면 정산됩니다.
+// payment.Amount가 0이면 남은 금액 전부를 받은 것으로 보고, Method가 비어 있으면 CreditMethod로 둡니다.
+// 이미 정산된 주문에 전액 정산(Amount 0)을 요청하면 바꾸지 않고 그대로 반환하므로 여러 번 호출해도 됩니다.
+// 입금액이 남은 금액보다 많으면 *OverpaymentError를, 취소된 주문이면 ErrOrderVoided를 반환합니다.
+func (o Order) ApplyPayment(payment CreditPayment) (Order, error) {
+	if o.IsVoided() {
+		return Order{}, ErrOrderVoided
+	}
+	if payment.Amount < 0 {
+		return Order{}, fmt.Errorf("payment amount %d is negative", payment.Amount)
+	}
+	if o.CreditStatus == CreditSettled && payment.Amount == 0 {
+		return o, nil
+	}
+	balance := o.Balance()
+	if payment.Amount == 0 {
+		payment.Amount = balance
+	}
+	if payment.Amount > balance {
+		return Order{}, &OverpaymentError{Balance: balance, Amount: payment.Amount}
+	}
+	if payment.Method == "" {
+		payment.Method = CreditMethod
+	}
+
+	paid := o.clone()
+	paid.CreditPayments = append(paid.CreditPayments, payment)
+	event := CreditEvent{
+		Type:     CreditEventPayment,
+		At:       payment.PaidAt,
+		Operator: payment.Operator,
+		Amount:   payment.Amount,
+		Method:   payment.Method,
+	}
+	if paid.Balance() == 0 {
+		paid.CreditStatus = CreditSettled
+		event.Type = CreditEventSettle
+	}
+	paid.CreditHistory = append(paid.CreditHistory, event)
+	return paid, nil
+}
+
+// IsCredit은 외상으로 받은 주문인지 확인합니다. 결제 수단에 CreditMethod가 있거나 외상 장부·이력이 있으면 외상 주문입니다.
+func (o Order) IsCredit() bool {
+	if len(o.CreditPayments) > 0 || len(o.CreditHistory) > 0 {
+		return true
+	}
+	for _, pm := range o.PaymentMethods {
+		if pm.Method == CreditMethod {
+			return true
+		}
+	}
+	return false
+}
+
+// Unsettle은 정산을 취소해 다시 미정산 외상으로 되돌린 주문을 반환합니다.
+// 정산한 입금(장부의 마지막 입금)을 같은 날짜·결제 수단의 음수 입금으로 상쇄하므로, 그날 매출에서 빠지고 남은 금액이 그만큼 늘어납니다.
+// 장부 없이 정산된 예전 주문은 상태만 되돌립니다. event에는 At, Operator, Reason을 채워 넘깁니다.
+// 외상 주문이 아니면 ErrNotCredit을, 정산되지 않았으면 ErrNotSettled를, 취소된 주문이면 ErrOrderVoided를 반환합니다.
+func (o Order) Unsettle(event CreditEvent) (Order, error) {
+	if o.IsVoided() {
+		return Order{}, ErrOrderVoided
+	}
+	if !o.IsCredit() {
+		return Order{}, ErrNotCredit
+	}
+	if o.CreditStatus != CreditSettled {
+		return Order{}, ErrNotSettled
+	}
+
+	unsettled := o.clone()
+	unsettled.CreditStatus = CreditUnpaid
+	event.Type = CreditEventUnsettle
+	event.Amount = o.CreditAmount()
+	if n := len(o.CreditPayments); n > 0 {
+		settlement := o.CreditPayments[n-1]
+		unsettled.CreditPayments = append(unsettled.CreditPayments, CreditPayment{
+			Amount:   -settlement.Amount,
+			Method:   settlement.Method,
+			Date:     settlement.Date,
+			PaidAt:   event.At,
+			Operator: event.Operator,
+		})
+		event.Amount = settlement.Amount
+		event.Method = settlement.Method
+	}
+	unsettled.CreditHistory = append(unsettled.CreditHistory, event)
+	return unsettled, nil
+}
+
+// Rollups는 주문이 일별 집계에 더하는 양을 날짜 오름차순으로 반환합니다.
+//   - 외상이 아니었거나 장부 없이 일괄 정산된 주문은 주문 전체가 orderDate에 잡힙니다.
+//   - 장부가 있거나 미정산인 외상 주문은 주문할 때 바로 받은 결제(외상이 아닌 결제 수단)가 orderDate에,
+//     외상 입금액이 받은 날에 잡히고, 메뉴 판매는 정산된 날(마지막 입금일)에 잡힙니다.
+//   - 취소된 주문은 아무 데도 잡히지 않습니다.
+func (o Order) Rollups() []Rollup {
+	if o.IsVoided() {
+		return nil
+	}
+	if len(o.CreditPayments) == 0 && o.CreditStatus == CreditSettled {
+		return []Rollup{RollupOf(o)}
+	}
+
+	byDate := make(map[string]Rollup)
+	add := func(date string, other Rollup) {
+		rollup, ok := byDate[date]
+		if !ok {
+			rollup = NewRollup(date)
+		}
+		rollup.Add(other, 1)
+		byDate[date] = rollup
+	}
+	if upfront := o.upfrontPayments(); len(upfront) > 0 {
+		received := NewRollup(o.OrderDate)
+		for _, pm := range upfront {
+			received.PaymentSales[pm.Method] += pm.Amount
+			received.Total += pm.Amount
+		}
+		add(o.OrderDate, received)
+	}
+	for _, payment := range o.CreditPayments {
+		collected := NewRollup(payment.Date)
+		collected.PaymentSales[payment.Method] = payment.Amount
+		collected.Total = payment.Amount
+		add(payment.Date, collected)
+	}
+	if o.CreditStatus == CreditSettled {
+		settledOn := o.CreditPayments[len(o.CreditPayments)-1].Date
+		add(settledOn, Rollup{MenuSales: RollupOf(o).MenuSales})
+	}
+
+	rollups := make([]Rollup, 0, len(byDate))
+	for _, rollup := range byDate {
+		rollups = append(rollups, rollup)
+	}
+	sort.Slice(rollups, func(i, j int) bool { return rollups[i].Date < rollups[j].Date })
+	return rollups
+}
+
+// RollupDelta는 주문이 before에서 after로 바뀔 때 일별 집계에 더할 양을 날짜 오름차순으로 반환합니다.
+// 주문이 새로 생기거나 없어지는 경우는 없는 쪽에 빈 Order를 넘깁니다. 바뀌지 않는 날짜는 빠집니다.
+func RollupDelta(before, after Order) []Rollup {
+	byDate := make(map[string]Rollup)
+	apply := func(order Order, sign int) {
+		if order.OrderDate == "" {
+			return
+		}
+		for _, other := range order.Rollups() {
+			rollup, ok := byDate[other.Date]
+			if !ok {
+				rollup = NewRollup(other.Date)
+			}
+			rollup.Add(other, sign)
+			byDate[other.Date] = rollup
+		}
+	}
+	apply(before, -1)
+	apply(after, 1)
+
+	var delta []Rollup
+	for _, rollup := range byDate {
+		if !rollup.IsZero() {
+			delta = append(delta, rollup.Compact())
+		}
+	}
+	sort.Slice(delta, func(i, j int) bool { return delta[i].Date < delta[j].Date })
+	return delta
+}
+
+// MaxBulkSettle은 SettleCredits 한 번에 정산할 수 있는 최대 주문 수입니다.
+// 주문마다 트랜잭션 항목 하나에 날짜별 집계 항목이 더해지므로 DynamoDB 트랜잭션 한도(100개) 안에 들도록 정했습니다.
+const MaxBulkSettle = 50
+
+// ErrTooManyOrders는 한 번에 정산하려는 주문이 MaxBulkSettle개보다 많을 때 반환됩니다.
+// 더 많은 주문은 MaxBulkSettle개씩 나눠 여러 번 호출합니다.
+var ErrTooManyOrders = errors.New("too many orders to settle at once")
+
+// SettleStatus는 일괄 정산에서 주문 하나의 결과입니다.
+type SettleStatus string
+
+const (
+	// SettleSettled는 남은 외상을 모두 받아 정산한 주문입니다.
+	SettleSettled SettleStatus = "settled"
+	// SettleAlreadySettled는 이미 정산되어 바꾸지 않은 주문입니다.
+	SettleAlreadySettled SettleStatus = "alreadySettled"
+	// SettleNotFound는 없는 주문입니다.
+	SettleNotFound SettleStatus = "notFound"
+	// SettleVoided는 취소되어 정산하지 않은 주문입니다.
+	SettleVoided SettleStatus = "voided"
+)
+
+// SettleOutcome은 일괄 정산에서 주문 하나의 결과와 이번에 받은 금액입니다.
+type SettleOutcome struct {
+	OrderID
+	Status SettleStatus `json:"status"`
+	Amount Won          `json:"amount"`
+}
+
+// settleOutcome은 주문의 남은 외상 전부를 payment 수단으로 받은 주문과 그 결과를 반환합니다.
+func settleOutcome(order Order, payment CreditPayment) (Order, SettleOutcome, error) {
+	id := OrderID{order.OrderDate, order.OrderNum}
+	if order.IsVoided() {
+		return order, SettleOutcome{OrderID: id, Status: SettleVoided}, nil
+	}
+	if order.CreditStatus == CreditSettled {
+		return order, SettleOutcome{OrderID: id, Status: SettleAlreadySettled}, nil
+	}
+	payment.Amount = 0
+	paid, err := order.ApplyPayment(payment)
+	if err != nil {
+		return Order{}, SettleOutcome{}, err
+	}
+	amount := paid.CreditPayments[len(paid.CreditPayments)-1].Amount
+	return paid, SettleOutcome{OrderID: id, Status: SettleSettled, Amount: amount}, nil
+}
+
+// uniqueOrderIDs는 처음 나온 순서를 지키며 겹치는 키를 뺍니다.
+func uniqueOrderIDs(ids []OrderID) []OrderID {
+	seen := make(map[OrderID]bool, len(ids))
+	var unique []OrderID
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// MergeRollups는 같은 날짜의 집계를 더해 날짜마다 하나로 합치고 날짜 오름차순으로 반환합니다.
+func MergeRollups(rollups []Rollup) []Rollup {
+	byDate := make(map[string]Rollup)
+	for _, other := range rollups {
+		rollup, ok := byDate[other.Date]
+		if !ok {
+			rollup = NewRollup(other.Date)
+		}
+		rollup.Add(other, 1)
+		byDate[other.Date] = rollup
+	}
+	merged := make([]Rollup, 0, len(byDate))
+	for _, rollup := range byDate {
+		if !rollup.IsZero() {
+			merged = append(merged, rollup.Compact())
+		}
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Date < merged[j].Date })
+	return merged
+}
@@ -0,0 +1,145 @@
+package holybean
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// CreditSort는 외상 목록 정렬 기준입니다.
+type CreditSort string
+
+const (
+	// CreditSortDate는 orderDate 순서입니다.
+	CreditSortDate CreditSort = "date"
+	// CreditSortAmount는 남은 외상 금액(Balance) 순서입니다.
+	CreditSortAmount CreditSort = "amount"
+	// CreditSortCustomer는 손님(CustomerKey) 순서입니다.
+	CreditSortCustomer CreditSort = "customer"
+)
+
+// ErrInvalidCursor는 외상 목록 커서를 읽을 수 없거나 다른 정렬로 만든 커서일 때 반환됩니다.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// CreditFilter는 외상 목록 조건입니다. 빈 값인 조건은 보지 않습니다.
+type CreditFilter struct {
+	// Customer는 손님 이름 일부입니다. 이름과 같은 규칙(CustomerKey)으로 정규화해 비교하며,
+	// 정규화해서 비는 값(공백이나 "님"뿐)은 조건이 없는 것으로 봅니다.
+	Customer string
+	// Start, End는 orderDate 범위(양 끝 포함, YYYY-MM-DD)입니다.
+	Start, End string
+	// MinBalance는 남은 외상 금액의 최솟값입니다.
+	MinBalance Won
+}
+
+// Match는 주문이 모든 조건에 맞는지 확인합니다.
+func (f CreditFilter) Match(order Order) bool {
+	if key := CustomerKey(f.Customer); key != "" && !strings.Contains(CustomerKey(order.CustomerName), key) {
+		return false
+	}
+	if f.Start != "" && order.OrderDate < f.Start {
+		return false
+	}
+	if f.End != "" && order.OrderDate > f.End {
+		return false
+	}
+	return order.Balance() >= f.MinBalance
+}
+
+// CreditCursor는 외상 목록에서 이미 돌려준 마지막 주문의 정렬 위치입니다.
+// 위치를 값으로 기억하므로 그 사이 앞쪽 주문이 정산되어 빠져도 건너뛰거나 겹치지 않습니다.
+type CreditCursor struct {
+	Sort      CreditSort `json:"s"`
+	Desc      bool       `json:"d,omitempty"`
+	Balance   Won        `json:"b,omitempty"`
+	Customer  string     `json:"c,omitempty"`
+	OrderDate string     `json:"od"`
+	OrderNum  int        `json:"on"`
+}
+
+// Encode는 커서를 응답에 실을 불투명한 문자열로 만듭니다.
+func (c CreditCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCreditCursor는 Encode로 만든 문자열을 읽습니다.
+func DecodeCreditCursor(token string) (CreditCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return CreditCursor{}, ErrInvalidCursor
+	}
+	var cursor CreditCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.OrderDate == "" {
+		return CreditCursor{}, ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+func creditCursorOf(order Order, by CreditSort, desc bool) CreditCursor {
+	return CreditCursor{
+		Sort:      by,
+		Desc:      desc,
+		Balance:   order.Balance(),
+		Customer:  CustomerKey(order.CustomerName),
+		OrderDate: order.OrderDate,
+		OrderNum:  order.OrderNum,
+	}
+}
+
+// before는 by 기준 오름차순에서 a가 b보다 앞인지 확인합니다. 같으면 (orderDate, orderNum) 순서입니다.
+func (a CreditCursor) before(b CreditCursor, by CreditSort) bool {
+	switch {
+	case by == CreditSortAmount && a.Balance != b.Balance:
+		return a.Balance < b.Balance
+	case by == CreditSortCustomer && a.Customer != b.Customer:
+		return a.Customer < b.Customer
+	case a.OrderDate != b.OrderDate:
+		return a.OrderDate < b.OrderDate
+	}
+	return a.OrderNum < b.OrderNum
+}
+
+// PageCredits는 orders를 by 순서(desc이면 역순)로 정렬해 after 다음부터 최대 limit개를 반환합니다.
+// orders는 조건에 맞는 미정산 외상 전체여야 합니다. 정렬 기준이 저장소 인덱스 순서와 다르므로 메모리에서 자릅니다.
+// 뒤에 더 있으면 다음 페이지 커서를, 없으면 nil을 반환합니다. limit이 0 이하이면 나머지를 모두 반환합니다.
+// after가 다른 정렬로 만든 커서이면 ErrInvalidCursor를 반환합니다.
+func PageCredits(orders []Order, by CreditSort, desc bool, after *CreditCursor, limit int) ([]Order, *CreditCursor, error) {
+	if after != nil && (after.Sort != by || after.Desc != desc) {
+		return nil, nil, ErrInvalidCursor
+	}
+	keys := make([]CreditCursor, len(orders))
+	sorted := make([]int, len(orders))
+	for i, order := range orders {
+		keys[i] = creditCursorOf(order, by, desc)
+		sorted[i] = i
+	}
+	ordered := func(a, b CreditCursor) bool {
+		if desc {
+			return b.before(a, by)
+		}
+		return a.before(b, by)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return ordered(keys[sorted[i]], keys[sorted[j]]) })
+
+	start := 0
+	if after != nil {
+		start = sort.Search(len(sorted), func(i int) bool { return ordered(*after, keys[sorted[i]]) })
+	}
+	end := len(sorted)
+	if limit > 0 && start+limit < end {
+		end = start + limit
+	}
+
+	page := make([]Order, 0, end-start)
+	for _, i := range sorted[start:end] {
+		page = append(page, orders[i])
+	}
+	if end == len(sorted) {
+		return page, nil, nil
+	}
+	next := keys[sorted[end-1]]
+	return page, &next, nil
+}
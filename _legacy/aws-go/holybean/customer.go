@@ -0,0 +1,65 @@
+package holybean
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// CustomerKey는 주문의 customerName을 손님 식별자로 정규화합니다.
+// 앱에서 이름을 자유롭게 입력하므로 공백을 모두 지우고, 영문은 소문자로 바꾸고, 끝의 존칭 "님"을 뗍니다.
+// 예: " 김철수 집사님", "김철수집사" → "김철수집사". 이름이 없으면 빈 문자열입니다.
+func CustomerKey(name string) string {
+	key := strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, name)
+	return strings.TrimSuffix(key, "님")
+}
+
+// CreditAccount는 한 손님의 미정산 외상 묶음입니다.
+type CreditAccount struct {
+	CustomerKey string `json:"customerKey"`
+	// CustomerName은 가장 최근 주문에 적힌 이름입니다.
+	CustomerName string `json:"customerName"`
+	Outstanding  Won    `json:"outstanding"`
+	// OldestUnpaidDate는 가장 오래된 미정산 주문의 orderDate입니다.
+	OldestUnpaidDate string `json:"oldestUnpaidDate"`
+	OrderCount       int    `json:"orderCount"`
+	// Orders는 이 손님의 미정산 주문을 orderDate 오름차순으로 담습니다.
+	Orders []Order `json:"-"`
+}
+
+// GroupCredits는 미정산 외상 주문을 CustomerKey별로 묶습니다.
+// orders는 orderDate 오름차순이어야 하며(ListOpenCredits 순서), 결과는 남은 금액이 큰 손님부터입니다.
+func GroupCredits(orders []Order) []CreditAccount {
+	byKey := make(map[string]*CreditAccount)
+	var keys []string
+	for _, order := range orders {
+		key := CustomerKey(order.CustomerName)
+		account, ok := byKey[key]
+		if !ok {
+			account = &CreditAccount{CustomerKey: key, OldestUnpaidDate: order.OrderDate}
+			byKey[key] = account
+			keys = append(keys, key)
+		}
+		account.CustomerName = strings.TrimSpace(order.CustomerName)
+		account.Outstanding += order.Balance()
+		account.OrderCount++
+		account.Orders = append(account.Orders, order)
+	}
+
+	accounts := make([]CreditAccount, len(keys))
+	for i, key := range keys {
+		accounts[i] = *byKey[key]
+	}
+	sort.SliceStable(accounts, func(i, j int) bool {
+		if accounts[i].Outstanding != accounts[j].Outstanding {
+			return accounts[i].Outstanding > accounts[j].Outstanding
+		}
+		return accounts[i].CustomerKey < accounts[j].CustomerKey
+	})
+	return accounts
+}
@@ -0,0 +1,47 @@
+package holybean
+
+import (
+	"context"
+	"os"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const (
+	// DefaultRegion은 AWS_REGION이 비어 있을 때 쓰는 리전입니다.
+	DefaultRegion = "ap-northeast-2"
+
+	// OrdersTable은 주문 테이블입니다. (PK: orderDate, SK: orderNum)
+	OrdersTable = "holybean"
+	// CreditStatusIndex는 creditStatus로 외상 주문을 조회하는 GSI입니다.
+	CreditStatusIndex = "creditStatus-index"
+	// MenuTable은 메뉴 버전 테이블입니다. (PK: pk, SK: sk)
+	MenuTable = "holybean-menu"
+	// MetaTable은 멱등성 키처럼 주문 테이블에 둘 수 없는 보조 항목을 담는 테이블입니다. (PK: pk, SK: sk)
+	MetaTable = "holybean-meta"
+)
+
+// NewDynamoDBClient는 AWS_REGION(없으면 DefaultRegion) 기준으로 DynamoDB 클라이언트를 만듭니다.
+// Lambda 콜드 스타트 때 한 번만 호출해 재사용하세요.
+func NewDynamoDBClient(ctx context.Context) (*dynamodb.Client, error) {
+	region := os.Getenv("AWS_REGION")
+	if region == "" {
+		region = DefaultRegion
+	}
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+	if err != nil {
+		return nil, err
+	}
+	return dynamodb.NewFromConfig(cfg), nil
+}
+
+// OrderKey는 holybean 테이블의 기본 키(orderDate, orderNum)를 만듭니다.
+func OrderKey(orderDate string, orderNum int) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"orderDate": &types.AttributeValueMemberS{Value: orderDate},
+		"orderNum":  &types.AttributeValueMemberN{Value: strconv.Itoa(orderNum)},
+	}
+}
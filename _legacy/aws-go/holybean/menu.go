@@ -0,0 +1,166 @@
+package holybean
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MenuPartition은 holybean-menu 테이블에서 메뉴 버전들이 모여 있는 파티션 키(pk)입니다.
+const MenuPartition = "default"
+
+// ErrMenuNotFound는 저장된 메뉴 버전이 없을 때 반환됩니다.
+var ErrMenuNotFound = errors.New("menu not found")
+
+// MenuTimestampLayout은 메뉴 버전 타임스탬프(sk) 형식입니다.
+// UTC 고정 길이 형식이라 문자열 정렬 순서가 시간 순서와 같습니다.
+const MenuTimestampLayout = "2006-01-02T15:04:05.000Z"
+
+// NewMenuTimestamp는 지금 시각의 메뉴 버전 타임스탬프를 만듭니다.
+func NewMenuTimestamp() string {
+	return time.Now().UTC().Format(MenuTimestampLayout)
+}
+
+// MenuEntry는 메뉴 한 개입니다. 앱의 MenuItem과 같은 필드를 쓰며, Category만 선택 값입니다.
+type MenuEntry struct {
+	ID        int    `json:"id" dynamodbav:"id"`
+	Name      string `json:"name" dynamodbav:"name"`
+	Price     Won    `json:"price" dynamodbav:"price"`
+	InUse     bool   `json:"inuse" dynamodbav:"inuse"`
+	Placement int    `json:"placement" dynamodbav:"placement"`
+	Category  string `json:"category,omitempty" dynamodbav:"category,omitempty"`
+}
+
+// Menu는 holybean-menu 테이블의 메뉴 버전 하나입니다. 정렬 키(sk)가 버전 타임스탬프입니다.
+type Menu struct {
+	Timestamp string      `dynamodbav:"sk"`
+	Entries   []MenuEntry `dynamodbav:"menu_items"`
+	// RestoredFrom은 이 버전이 이전 버전을 되살려 만든 것일 때 원래 버전의 타임스탬프입니다.
+	RestoredFrom string `dynamodbav:"restoredFrom,omitempty"`
+	// Invalid는 저장소에서 읽을 때 형식이 잘못돼 Entries에서 뺀 항목입니다. 저장되지 않습니다.
+	Invalid []FieldError `dynamodbav:"-"`
+}
+
+// Sorted는 메뉴를 placement 오름차순(같으면 id 오름차순)으로 정렬한 새 목록을 반환합니다.
+// inUseOnly가 true이면 inuse=false인 메뉴를 뺍니다.
+func (m Menu) Sorted(inUseOnly bool) []MenuEntry {
+	entries := make([]MenuEntry, 0, len(m.Entries))
+	for _, entry := range m.Entries {
+		if inUseOnly && !entry.InUse {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Placement != entries[j].Placement {
+			return entries[i].Placement < entries[j].Placement
+		}
+		return entries[i].ID < entries[j].ID
+	})
+	return entries
+}
+
+// MenuRepository는 메뉴 버전 저장소입니다.
+type MenuRepository interface {
+	// Latest는 가장 최근 메뉴 버전을 반환합니다. 없으면 ErrMenuNotFound를 반환합니다.
+	Latest(ctx context.Context) (Menu, error)
+	// Get은 타임스탬프로 메뉴 버전 하나를 조회합니다. 없으면 ErrMenuNotFound를 반환합니다.
+	Get(ctx context.Context, timestamp string) (Menu, error)
+	// List는 모든 메뉴 버전을 최신순으로 반환합니다.
+	List(ctx context.Context) ([]Menu, error)
+	// Save는 새 메뉴 버전을 저장합니다. 기존 버전은 그대로 남습니다.
+	Save(ctx context.Context, version Menu) error
+}
+
+// MenuRequest는 save_menulist 요청 본문입니다. GET /menu 응답의 menulist와 같은 모양입니다.
+type MenuRequest struct {
+	MenuList []MenuEntryRequest `json:"menulist"`
+}
+
+// DecodeMenuRequest는 JSON을 엄격하게 MenuRequest로 읽습니다. 모르는 필드가 있으면 오류입니다.
+func DecodeMenuRequest(data []byte) (MenuRequest, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var request MenuRequest
+	if err := decoder.Decode(&request); err != nil {
+		return MenuRequest{}, err
+	}
+	return request, nil
+}
+
+// MenuEntryRequest는 요청 본문의 메뉴 한 개입니다.
+// 포인터(*) 필드는 누락과 0/false를 구분하기 위한 필수 값입니다.
+type MenuEntryRequest struct {
+	ID        *int    `json:"id"`
+	Name      *string `json:"name"`
+	Price     *Won    `json:"price"`
+	InUse     *bool   `json:"inuse"`
+	Placement *int    `json:"placement"`
+	Category  string  `json:"category"` // Optional 필드
+}
+
+// Validate는 메뉴 목록을 검사하고 잘못된 항목을 모두 반환합니다.
+//   - menulist는 비어 있으면 안 됩니다.
+//   - 모든 필드가 있어야 하고, name은 공백일 수 없습니다.
+//   - id는 양수, price와 placement는 0 이상이어야 합니다.
+//   - id와 name은 목록 안에서 겹치면 안 됩니다.
+func (r MenuRequest) Validate() []FieldError {
+	var errs []FieldError
+	if len(r.MenuList) == 0 {
+		errs = append(errs, FieldError{"menulist", "메뉴가 하나 이상 있어야 합니다"})
+	}
+
+	ids := make(map[int]int)
+	names := make(map[string]int)
+	for i, entry := range r.MenuList {
+		field := func(name string) string { return fmt.Sprintf("menulist[%d].%s", i, name) }
+		if entry.ID == nil || entry.Name == nil || entry.Price == nil || entry.InUse == nil || entry.Placement == nil {
+			errs = append(errs, FieldError{fmt.Sprintf("menulist[%d]", i), "id, name, price, inuse, placement가 모두 필요합니다"})
+			continue
+		}
+		if *entry.ID <= 0 {
+			errs = append(errs, FieldError{field("id"), fmt.Sprintf("id는 양수여야 합니다: %d", *entry.ID)})
+		} else if first, ok := ids[*entry.ID]; ok {
+			errs = append(errs, FieldError{field("id"), fmt.Sprintf("menulist[%d]와 id가 겹칩니다: %d", first, *entry.ID)})
+		} else {
+			ids[*entry.ID] = i
+		}
+		name := strings.TrimSpace(*entry.Name)
+		if name == "" {
+			errs = append(errs, FieldError{field("name"), "이름이 비어 있습니다"})
+		} else if first, ok := names[name]; ok {
+			errs = append(errs, FieldError{field("name"), fmt.Sprintf("menulist[%d]와 이름이 겹칩니다: %s", first, name)})
+		} else {
+			names[name] = i
+		}
+		if *entry.Price < 0 {
+			errs = append(errs, FieldError{field("price"), fmt.Sprintf("가격은 음수일 수 없습니다: %d", *entry.Price)})
+		}
+		if *entry.Placement < 0 {
+			errs = append(errs, FieldError{field("placement"), fmt.Sprintf("placement는 음수일 수 없습니다: %d", *entry.Placement)})
+		}
+	}
+	return errs
+}
+
+// Entries는 요청을 저장용 MenuEntry 목록으로 변환합니다. 이름 앞뒤 공백은 지웁니다.
+// Validate가 오류를 반환하지 않을 때만 호출해야 합니다.
+func (r MenuRequest) Entries() []MenuEntry {
+	entries := make([]MenuEntry, len(r.MenuList))
+	for i, entry := range r.MenuList {
+		entries[i] = MenuEntry{
+			ID:        *entry.ID,
+			Name:      strings.TrimSpace(*entry.Name),
+			Price:     *entry.Price,
+			InUse:     *entry.InUse,
+			Placement: *entry.Placement,
+			Category:  strings.TrimSpace(entry.Category),
+		}
+	}
+	return entries
+}
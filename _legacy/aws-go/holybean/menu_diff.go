@@ -0,0 +1,69 @@
+package holybean
+
+import "sort"
+
+// MenuDiff는 두 메뉴 버전 사이의 차이입니다. 메뉴는 id로 짝지으며 각 목록은 id 오름차순입니다.
+type MenuDiff struct {
+	Added   []MenuEntry  `json:"added"`
+	Removed []MenuEntry  `json:"removed"`
+	Changed []MenuChange `json:"changed"`
+}
+
+// MenuChange는 같은 id 메뉴의 변경 내용입니다. Fields는 바뀐 필드의 JSON 이름입니다.
+type MenuChange struct {
+	ID     int       `json:"id"`
+	Fields []string  `json:"fields"`
+	Before MenuEntry `json:"before"`
+	After  MenuEntry `json:"after"`
+}
+
+// DiffMenus는 from에서 to로 바뀐 메뉴를 구합니다.
+func DiffMenus(from, to []MenuEntry) MenuDiff {
+	diff := MenuDiff{Added: []MenuEntry{}, Removed: []MenuEntry{}, Changed: []MenuChange{}}
+	before := make(map[int]MenuEntry, len(from))
+	for _, entry := range from {
+		before[entry.ID] = entry
+	}
+	after := make(map[int]MenuEntry, len(to))
+	for _, entry := range to {
+		after[entry.ID] = entry
+		old, ok := before[entry.ID]
+		if !ok {
+			diff.Added = append(diff.Added, entry)
+			continue
+		}
+		if fields := changedMenuFields(old, entry); len(fields) > 0 {
+			diff.Changed = append(diff.Changed, MenuChange{ID: entry.ID, Fields: fields, Before: old, After: entry})
+		}
+	}
+	for _, entry := range from {
+		if _, ok := after[entry.ID]; !ok {
+			diff.Removed = append(diff.Removed, entry)
+		}
+	}
+
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].ID < diff.Added[j].ID })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].ID < diff.Removed[j].ID })
+	sort.Slice(diff.Changed, func(i, j int) bool { return diff.Changed[i].ID < diff.Changed[j].ID })
+	return diff
+}
+
+func changedMenuFields(a, b MenuEntry) []string {
+	var fields []string
+	if a.Name != b.Name {
+		fields = append(fields, "name")
+	}
+	if a.Price != b.Price {
+		fields = append(fields, "price")
+	}
+	if a.InUse != b.InUse {
+		fields = append(fields, "inuse")
+	}
+	if a.Placement != b.Placement {
+		fields = append(fields, "placement")
+	}
+	if a.Category != b.Category {
+		fields = append(fields, "category")
+	}
+	return fields
+}
@@ -0,0 +1,184 @@
+package holybean
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// DynamoMenuRepository는 holybean-menu 테이블을 사용하는 MenuRepository입니다.
+type DynamoMenuRepository struct {
+	client *dynamodb.Client
+	table  string
+}
+
+// NewDynamoMenuRepository는 MenuTable을 사용하는 저장소를 만듭니다.
+func NewDynamoMenuRepository(client *dynamodb.Client) *DynamoMenuRepository {
+	return &DynamoMenuRepository{client: client, table: MenuTable}
+}
+
+func (r *DynamoMenuRepository) Latest(ctx context.Context) (Menu, error) {
+	result, err := r.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(r.table),
+		KeyConditionExpression: aws.String("pk = :pk"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk": &types.AttributeValueMemberS{Value: MenuPartition},
+		},
+		ScanIndexForward: aws.Bool(false), // Sort descending
+		Limit:            aws.Int32(1),
+	})
+	if err != nil {
+		return Menu{}, err
+	}
+	if len(result.Items) == 0 {
+		return Menu{}, ErrMenuNotFound
+	}
+	return unmarshalMenu(result.Items[0])
+}
+
+func (r *DynamoMenuRepository) Get(ctx context.Context, timestamp string) (Menu, error) {
+	result, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.table),
+		Key: map[string]types.AttributeValue{
+			"pk": &types.AttributeValueMemberS{Value: MenuPartition},
+			"sk": &types.AttributeValueMemberS{Value: timestamp},
+		},
+	})
+	if err != nil {
+		return Menu{}, err
+	}
+	if result.Item == nil {
+		return Menu{}, ErrMenuNotFound
+	}
+	return unmarshalMenu(result.Item)
+}
+
+func (r *DynamoMenuRepository) List(ctx context.Context) ([]Menu, error) {
+	paginator := dynamodb.NewQueryPaginator(r.client, &dynamodb.QueryInput{
+		TableName:              aws.String(r.table),
+		KeyConditionExpression: aws.String("pk = :pk"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk": &types.AttributeValueMemberS{Value: MenuPartition},
+		},
+		ScanIndexForward: aws.Bool(false), // Sort descending
+	})
+	var versions []Menu
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page.Items {
+			version, err := unmarshalMenu(item)
+			if err != nil {
+				return nil, err
+			}
+			versions = append(versions, version)
+		}
+	}
+	return versions, nil
+}
+
+func (r *DynamoMenuRepository) Save(ctx context.Context, version Menu) error {
+	item, err := attributevalue.MarshalMap(version)
+	if err != nil {
+		return fmt.Errorf("marshal menu version: %w", err)
+	}
+	item["pk"] = &types.AttributeValueMemberS{Value: MenuPartition}
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(r.table),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(sk)"),
+	})
+	var conditionCheckErr *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionCheckErr) {
+		return fmt.Errorf("menu version %s already exists", version.Timestamp)
+	}
+	return err
+}
+
+// unmarshalMenu는 메뉴 버전 항목을 읽습니다. 메뉴 하나의 형식이 잘못됐으면 버전 전체를 버리지 않고
+// 그 메뉴만 Entries에서 빼 Invalid에 기록합니다.
+func unmarshalMenu(item map[string]types.AttributeValue) (Menu, error) {
+	sk, ok := item["sk"].(*types.AttributeValueMemberS)
+	if !ok {
+		return Menu{}, errors.New("unmarshal menu version: sk is missing or not a string")
+	}
+	menu := Menu{Timestamp: sk.Value}
+	if restoredFrom, ok := item["restoredFrom"].(*types.AttributeValueMemberS); ok {
+		menu.RestoredFrom = restoredFrom.Value
+	}
+
+	list, ok := item["menu_items"].(*types.AttributeValueMemberL)
+	if !ok {
+		return Menu{}, fmt.Errorf("unmarshal menu version %s: menu_items is missing or not a list", menu.Timestamp)
+	}
+	for i, value := range list.Value {
+		entry, err := unmarshalMenuEntry(value)
+		if err != nil {
+			menu.Invalid = append(menu.Invalid, FieldError{fmt.Sprintf("menulist[%d]", i), err.Error()})
+			continue
+		}
+		menu.Entries = append(menu.Entries, entry)
+	}
+	return menu, nil
+}
+
+// unmarshalMenuEntry는 메뉴 하나를 읽습니다. 필수 필드가 없거나 타입이 다르면 오류입니다.
+func unmarshalMenuEntry(value types.AttributeValue) (MenuEntry, error) {
+	m, ok := value.(*types.AttributeValueMemberM)
+	if !ok {
+		return MenuEntry{}, errors.New("메뉴가 맵(M)이 아닙니다")
+	}
+	var entry MenuEntry
+	var err error
+	if entry.ID, err = intAttribute(m.Value, "id"); err != nil {
+		return MenuEntry{}, err
+	}
+	price, ok := m.Value["price"]
+	if !ok {
+		return MenuEntry{}, errors.New("price가 없습니다")
+	}
+	if err := entry.Price.UnmarshalDynamoDBAttributeValue(price); err != nil {
+		return MenuEntry{}, fmt.Errorf("price: %w", err)
+	}
+	if entry.Placement, err = intAttribute(m.Value, "placement"); err != nil {
+		return MenuEntry{}, err
+	}
+	name, ok := m.Value["name"].(*types.AttributeValueMemberS)
+	if !ok || name.Value == "" {
+		return MenuEntry{}, errors.New("name이 없거나 문자열(S)이 아닙니다")
+	}
+	entry.Name = name.Value
+	inUse, ok := m.Value["inuse"].(*types.AttributeValueMemberBOOL)
+	if !ok {
+		return MenuEntry{}, errors.New("inuse가 없거나 불리언(BOOL)이 아닙니다")
+	}
+	entry.InUse = inUse.Value
+	if category, ok := m.Value["category"]; ok {
+		s, ok := category.(*types.AttributeValueMemberS)
+		if !ok {
+			return MenuEntry{}, errors.New("category가 문자열(S)이 아닙니다")
+		}
+		entry.Category = s.Value
+	}
+	return entry, nil
+}
+
+func intAttribute(m map[string]types.AttributeValue, name string) (int, error) {
+	n, ok := m[name].(*types.AttributeValueMemberN)
+	if !ok {
+		return 0, fmt.Errorf("%s가 없거나 숫자(N)가 아닙니다", name)
+	}
+	value, err := strconv.Atoi(n.Value)
+	if err != nil {
+		return 0, fmt.Errorf("%s가 정수가 아닙니다: %s", name, n.Value)
+	}
+	return value, nil
+}
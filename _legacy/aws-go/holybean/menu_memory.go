@@ -0,0 +1,66 @@
+package holybean
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// MemoryMenuRepository는 프로세스 메모리에 메뉴 버전을 보관하는 MenuRepository입니다.
+type MemoryMenuRepository struct {
+	mu       sync.RWMutex
+	versions []Menu // Timestamp 오름차순
+}
+
+// NewMemoryMenuRepository는 주어진 버전들로 채운 메모리 저장소를 만듭니다.
+func NewMemoryMenuRepository(versions ...Menu) *MemoryMenuRepository {
+	r := &MemoryMenuRepository{versions: append([]Menu(nil), versions...)}
+	sort.Slice(r.versions, func(i, j int) bool {
+		return r.versions[i].Timestamp < r.versions[j].Timestamp
+	})
+	return r
+}
+
+func (r *MemoryMenuRepository) Latest(ctx context.Context) (Menu, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.versions) == 0 {
+		return Menu{}, ErrMenuNotFound
+	}
+	return r.versions[len(r.versions)-1], nil
+}
+
+func (r *MemoryMenuRepository) Get(ctx context.Context, timestamp string) (Menu, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, version := range r.versions {
+		if version.Timestamp == timestamp {
+			return version, nil
+		}
+	}
+	return Menu{}, ErrMenuNotFound
+}
+
+func (r *MemoryMenuRepository) List(ctx context.Context) ([]Menu, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	versions := make([]Menu, len(r.versions))
+	for i, version := range r.versions {
+		versions[len(versions)-1-i] = version
+	}
+	return versions, nil
+}
+
+func (r *MemoryMenuRepository) Save(ctx context.Context, version Menu) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := sort.Search(len(r.versions), func(i int) bool { return r.versions[i].Timestamp >= version.Timestamp })
+	if i < len(r.versions) && r.versions[i].Timestamp == version.Timestamp {
+		return fmt.Errorf("menu version %s already exists", version.Timestamp)
+	}
+	r.versions = append(r.versions, Menu{})
+	copy(r.versions[i+1:], r.versions[i:])
+	r.versions[i] = version
+	return nil
+}
@@ -0,0 +1,87 @@
+package holybean
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Won은 원화 금액입니다. 원에는 소수 단위가 없으므로 정수로만 다룹니다.
+// JSON과 DynamoDB에서 읽을 때 정수가 아닌 값은 0으로 바꾸지 않고 오류로 돌려줍니다.
+type Won int64
+
+// String은 금액을 10진수 문자열로 반환합니다. DynamoDB N 값에도 씁니다.
+func (w Won) String() string {
+	return strconv.FormatInt(int64(w), 10)
+}
+
+// ParseWon은 10진수 문자열을 Won으로 읽습니다.
+// "2500.0"처럼 소수부가 0인 값은 받아들이고, "2500.5"나 범위를 넘는 값은 오류입니다.
+func ParseWon(s string) (Won, error) {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return Won(n), nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+		return 0, fmt.Errorf("금액이 숫자가 아닙니다: %q", s)
+	}
+	if f != math.Trunc(f) {
+		return 0, fmt.Errorf("금액은 원 단위 정수여야 합니다: %s", s)
+	}
+	// float64(math.MaxInt64)는 2^63으로 반올림되므로 같은 값도 범위 밖입니다.
+	if f >= math.MaxInt64 || f < math.MinInt64 {
+		return 0, fmt.Errorf("금액이 너무 큽니다: %s", s)
+	}
+	return Won(f), nil
+}
+
+// addWon은 음수가 아닌 a+b를 반환합니다. int64 범위를 넘으면 ok가 false입니다.
+func addWon(a, b Won) (sum Won, ok bool) {
+	if a > math.MaxInt64-b {
+		return 0, false
+	}
+	return a + b, true
+}
+
+// mulWon은 음수가 아닌 n×w를 반환합니다. int64 범위를 넘으면 ok가 false입니다.
+func mulWon(n int, w Won) (product Won, ok bool) {
+	if w != 0 && Won(n) > math.MaxInt64/w {
+		return 0, false
+	}
+	return Won(n) * w, true
+}
+
+func (w *Won) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return fmt.Errorf("금액이 숫자가 아닙니다: %s", data)
+	}
+	parsed, err := ParseWon(number.String())
+	if err != nil {
+		return err
+	}
+	*w = parsed
+	return nil
+}
+
+func (w *Won) UnmarshalDynamoDBAttributeValue(value types.AttributeValue) error {
+	switch v := value.(type) {
+	case *types.AttributeValueMemberN:
+		parsed, err := ParseWon(v.Value)
+		if err != nil {
+			return err
+		}
+		*w = parsed
+		return nil
+	case *types.AttributeValueMemberNULL:
+		return nil
+	default:
+		return fmt.Errorf("금액이 숫자(N)가 아닙니다: %T", value)
+	}
+}
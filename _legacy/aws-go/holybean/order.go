@@ -0,0 +1,82 @@
+// Package holybean은 HolyBean Lambda 함수들이 공유하는 도메인 모델과
+// API 응답 헬퍼, DynamoDB 클라이언트 생성 로직을 제공합니다.
+package holybean
+
+import "strings"
+
+// CreditStatus는 주문의 외상 여부입니다. creditStatus-index GSI의 파티션 키로도 쓰입니다.
+type CreditStatus int
+
+const (
+	// CreditSettled는 결제가 끝난(정산된) 주문입니다. 매출 리포트에는 이 상태의 주문만 집계됩니다.
+	CreditSettled CreditStatus = 0
+	// CreditUnpaid는 아직 결제되지 않은 외상 주문입니다.
+	CreditUnpaid CreditStatus = 1
+)
+
+// OrderItem은 주문에 포함된 메뉴 한 줄입니다.
+type OrderItem struct {
+	ItemName  string `json:"itemName" dynamodbav:"itemName"`
+	Quantity  int    `json:"quantity" dynamodbav:"quantity"`
+	Subtotal  Won    `json:"subtotal" dynamodbav:"subtotal"`
+	UnitPrice Won    `json:"unitPrice" dynamodbav:"unitPrice"`
+}
+
+// PaymentMethod는 주문 금액 중 한 결제 수단으로 치른 부분입니다. 복수 결제면 여러 개가 됩니다.
+type PaymentMethod struct {
+	Method string `json:"method" dynamodbav:"method"`
+	Amount Won    `json:"amount" dynamodbav:"amount"`
+}
+
+// Order는 holybean 테이블에 저장되는 주문 한 건입니다.
+// orderDate(YYYY-MM-DD)가 파티션 키, orderNum이 정렬 키입니다.
+type Order struct {
+	OrderDate      string          `json:"orderDate" dynamodbav:"orderDate"`
+	OrderNum       int             `json:"orderNum" dynamodbav:"orderNum"`
+	TotalAmount    Won             `json:"totalAmount" dynamodbav:"totalAmount"`
+	CustomerName   string          `json:"customerName" dynamodbav:"customerName,omitempty"`
+	PaymentMethods []PaymentMethod `json:"paymentMethods" dynamodbav:"paymentMethods"`
+	OrderItems     []OrderItem     `json:"orderItems" dynamodbav:"orderItems"`
+	CreditStatus   CreditStatus    `json:"creditStatus" dynamodbav:"creditStatus"`
+	// CreditPayments는 외상 장부입니다. 받은 순서대로 입금 내역이 쌓입니다.
+	CreditPayments []CreditPayment `json:"creditPayments,omitempty" dynamodbav:"creditPayments,omitempty"`
+	// CreditHistory는 입금·정산·정산 취소 이력입니다. 언제, 누가, 어떤 수단으로 바꿨는지 순서대로 쌓입니다.
+	CreditHistory []CreditEvent `json:"creditHistory,omitempty" dynamodbav:"creditHistory,omitempty"`
+	// CreatedAt은 서버가 주문을 받은 시각(Asia/Seoul, RFC 3339)입니다. 이 필드가 생기기 전 주문에는 없습니다.
+	CreatedAt string `json:"createdAt,omitempty" dynamodbav:"createdAt,omitempty"`
+	// Voided는 취소 기록입니다. 취소되지 않은 주문에는 없습니다.
+	Voided *OrderVoid `json:"voided,omitempty" dynamodbav:"voided,omitempty"`
+	// Version은 주문을 고친 횟수입니다. 처음 저장한 주문은 0이며, 고칠 때 읽은 값을 함께 보내 동시 수정을 막습니다.
+	Version int `json:"version" dynamodbav:"version,omitempty"`
+	// Revisions는 고치기 전 주문 내용을 오래된 것부터 담습니다.
+	Revisions []OrderRevision `json:"revisions,omitempty" dynamodbav:"revisions,omitempty"`
+	// IdempotencyKey는 주문을 만든 요청의 Idempotency-Key 헤더 값입니다. 재시도 판별에만 쓰입니다.
+	IdempotencyKey string `json:"-" dynamodbav:"idempotencyKey,omitempty"`
+}
+
+// MethodLabel은 결제 수단 이름을 "+"로 이어 붙인 표시용 문자열을 반환합니다.
+// 결제 수단이 없으면 "Unknown"입니다.
+func (o Order) MethodLabel() string {
+	if len(o.PaymentMethods) == 0 {
+		return "Unknown"
+	}
+	methods := make([]string, len(o.PaymentMethods))
+	for i, pm := range o.PaymentMethods {
+		methods[i] = pm.Method
+	}
+	return strings.Join(methods, "+")
+}
+
+// clone은 슬라이스까지 복사해 저장소 밖에서의 수정이 보관 중인 주문에 영향을 주지 않게 합니다.
+func (o Order) clone() Order {
+	o.OrderItems = append([]OrderItem(nil), o.OrderItems...)
+	o.PaymentMethods = append([]PaymentMethod(nil), o.PaymentMethods...)
+	o.CreditPayments = append([]CreditPayment(nil), o.CreditPayments...)
+	o.CreditHistory = append([]CreditEvent(nil), o.CreditHistory...)
+	o.Revisions = append([]OrderRevision(nil), o.Revisions...)
+	if o.Voided != nil {
+		void := *o.Voided
+		o.Voided = &void
+	}
+	return o
+}
@@ -0,0 +1,39 @@
+package holybean
+
+import (
+	"context"
+	"errors"
+)
+
+// AllocateOrderNum은 orderDate 날짜에서 아직 쓰이지 않은 주문 번호를 하나 받아 옵니다.
+//
+// 번호는 NextOrderNum 카운터에서 받으므로 동시에 요청해도 겹치지 않습니다.
+// 카운터를 쓰기 전에 저장된 주문이나 클라이언트가 번호를 직접 정한 주문이 카운터보다 앞서 있으면,
+// 카운터를 그날 가장 큰 번호까지 한 번에 올린 뒤 다음 번호를 받아 그 번호들을 건너뜁니다.
+// 빈 번호 수와 관계없이 쓰기는 두 번입니다.
+func AllocateOrderNum(ctx context.Context, orders OrderRepository, orderDate string) (int, error) {
+	orderNum, err := orders.NextOrderNum(ctx, orderDate)
+	if err != nil {
+		return 0, err
+	}
+	_, err = orders.Get(ctx, orderDate, orderNum)
+	if errors.Is(err, ErrOrderNotFound) {
+		return orderNum, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	dayOrders, err := orders.ListByDate(ctx, orderDate)
+	if err != nil {
+		return 0, err
+	}
+	last := orderNum
+	if n := len(dayOrders); n > 0 && dayOrders[n-1].OrderNum > last {
+		last = dayOrders[n-1].OrderNum
+	}
+	if err := orders.RaiseOrderCounter(ctx, orderDate, last); err != nil {
+		return 0, err
+	}
+	return orders.NextOrderNum(ctx, orderDate)
+}
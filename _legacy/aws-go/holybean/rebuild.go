@@ -0,0 +1,65 @@
+package holybean
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrOrderChanged는 관리 도구가 읽은 뒤 주문이 바뀌었거나 지워져 쓰지 않았을 때 반환됩니다.
+var ErrOrderChanged = errors.New("order changed since it was read")
+
+// RebuildRepository는 주문에서 파생 데이터(일별 집계, 주문 번호 카운터)를 다시 만드는 관리 도구용 저장소입니다.
+// 핸들러는 쓰지 않습니다.
+type RebuildRepository interface {
+	OrderRepository
+	// ForEach는 모든 주문을 순서 없이 한 번씩 fn에 넘깁니다. 테이블 전체를 읽습니다.
+	// fn이 오류를 반환하면 멈추고 그 오류를 반환합니다.
+	ForEach(ctx context.Context, fn func(Order) error) error
+	// PutRollup은 그 날짜의 집계를 rollup으로 통째로 바꿉니다.
+	PutRollup(ctx context.Context, rollup Rollup) error
+	// DeleteRollup은 그 날짜의 집계를 지웁니다.
+	DeleteRollup(ctx context.Context, date string) error
+	// OrderCounters는 [start, end] 날짜의 주문 번호 카운터 값을 날짜별로 반환합니다.
+	OrderCounters(ctx context.Context, start, end string) (map[string]int, error)
+	// MoveOrder는 order를 (orderDate, orderNum) 키로 옮기고 옮긴 주문을 반환합니다.
+	// 정산 완료 주문이면 두 날짜의 집계와 멱등성 키 기록도 함께 고칩니다.
+	// 새 키에 주문이 있으면 *OrderConflictError를, 읽은 뒤 원래 주문이 바뀌었으면 ErrOrderChanged를 반환합니다.
+	MoveOrder(ctx context.Context, order Order, orderDate string, orderNum int) (Order, error)
+}
+
+// Derived는 주문에서 계산한 파생 데이터입니다. firebase/src/rebuild.ts의 rebuildDerived와 같은 규칙을 따릅니다.
+type Derived struct {
+	// Rollups는 날짜별 집계입니다. 주문마다 Order.Rollups가 더하는 양의 합입니다.
+	Rollups map[string]Rollup
+	// LastOrderNums는 날짜별 가장 큰 주문 번호입니다.
+	LastOrderNums map[string]int
+	// OpenCredits는 취소되지 않은 미정산 외상 주문입니다.
+	OpenCredits map[OrderID]Order
+}
+
+// NewDerived는 비어 있는 Derived를 만듭니다.
+func NewDerived() *Derived {
+	return &Derived{
+		Rollups:       make(map[string]Rollup),
+		LastOrderNums: make(map[string]int),
+		OpenCredits:   make(map[OrderID]Order),
+	}
+}
+
+// Add는 주문 한 건을 파생 데이터에 반영합니다.
+func (d *Derived) Add(order Order) {
+	if order.OrderNum > d.LastOrderNums[order.OrderDate] {
+		d.LastOrderNums[order.OrderDate] = order.OrderNum
+	}
+	if order.CreditStatus != CreditSettled && !order.IsVoided() {
+		d.OpenCredits[OrderID{order.OrderDate, order.OrderNum}] = order
+	}
+	for _, other := range order.Rollups() {
+		rollup, ok := d.Rollups[other.Date]
+		if !ok {
+			rollup = NewRollup(other.Date)
+		}
+		rollup.Add(other, 1)
+		d.Rollups[other.Date] = rollup
+	}
+}
@@ -0,0 +1,142 @@
+package holybean
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func (r *DynamoOrderRepository) ForEach(ctx context.Context, fn func(Order) error) error {
+	paginator := dynamodb.NewScanPaginator(r.client, &dynamodb.ScanInput{
+		TableName: aws.String(r.table),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+		for _, item := range page.Items {
+			order, err := unmarshalOrder(item)
+			if err != nil {
+				return err
+			}
+			if err := fn(order); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *DynamoOrderRepository) PutRollup(ctx context.Context, rollup Rollup) error {
+	_, err := r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.metaTable),
+		Item:      rollupItem(rollup),
+	})
+	return err
+}
+
+func (r *DynamoOrderRepository) DeleteRollup(ctx context.Context, date string) error {
+	_, err := r.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(r.metaTable),
+		Key: map[string]types.AttributeValue{
+			"pk": &types.AttributeValueMemberS{Value: rollupPartition},
+			"sk": &types.AttributeValueMemberS{Value: date},
+		},
+	})
+	return err
+}
+
+func (r *DynamoOrderRepository) OrderCounters(ctx context.Context, start, end string) (map[string]int, error) {
+	paginator := dynamodb.NewQueryPaginator(r.client, &dynamodb.QueryInput{
+		TableName:              aws.String(r.metaTable),
+		KeyConditionExpression: aws.String("pk = :pk AND sk BETWEEN :start AND :end"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":    &types.AttributeValueMemberS{Value: orderCounterPartition},
+			":start": &types.AttributeValueMemberS{Value: start},
+			":end":   &types.AttributeValueMemberS{Value: end},
+		},
+	})
+	counters := make(map[string]int)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page.Items {
+			sk, ok := item["sk"].(*types.AttributeValueMemberS)
+			if !ok {
+				return nil, errors.New("unmarshal order counter: sk is missing or not a string")
+			}
+			n, err := intAttribute(item, "lastOrderNum")
+			if err != nil {
+				return nil, fmt.Errorf("unmarshal order counter %s: %w", sk.Value, err)
+			}
+			counters[sk.Value] = n
+		}
+	}
+	return counters, nil
+}
+
+// MoveOrder는 원래 주문 삭제, 새 키로 저장, 집계 이동, 멱등성 키 기록 갱신을 한 트랜잭션으로 합니다.
+// 외상 입금은 받은 날에 잡히므로 옮겨도 그 집계는 그대로입니다.
+func (r *DynamoOrderRepository) MoveOrder(ctx context.Context, order Order, orderDate string, orderNum int) (Order, error) {
+	moved := order
+	moved.OrderDate, moved.OrderNum = orderDate, orderNum
+	item, err := attributevalue.MarshalMap(moved)
+	if err != nil {
+		return Order{}, fmt.Errorf("marshal order: %w", err)
+	}
+
+	condition, values := orderUnchanged(order)
+	transactItems := []types.TransactWriteItem{
+		{Delete: &types.Delete{
+			TableName:                 aws.String(r.table),
+			Key:                       OrderKey(order.OrderDate, order.OrderNum),
+			ConditionExpression:       aws.String(condition),
+			ExpressionAttributeValues: values,
+		}},
+		{Put: &types.Put{
+			TableName:                           aws.String(r.table),
+			Item:                                item,
+			ConditionExpression:                 aws.String(orderNotExists),
+			ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+		}},
+	}
+	if order.IdempotencyKey != "" {
+		transactItems = append(transactItems, types.TransactWriteItem{Update: &types.Update{
+			TableName: aws.String(r.metaTable),
+			Key: map[string]types.AttributeValue{
+				"pk": &types.AttributeValueMemberS{Value: idempotencyPartition},
+				"sk": &types.AttributeValueMemberS{Value: order.IdempotencyKey},
+			},
+			UpdateExpression: aws.String("SET orderDate = :orderDate, orderNum = :orderNum"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":orderDate": &types.AttributeValueMemberS{Value: orderDate},
+				":orderNum":  &types.AttributeValueMemberN{Value: strconv.Itoa(orderNum)},
+			},
+		}})
+	}
+
+	err = r.writeWithRollups(ctx, transactItems, RollupDelta(order, moved))
+	if err == nil {
+		return moved, nil
+	}
+	var canceled *types.TransactionCanceledException
+	if !errors.As(err, &canceled) {
+		return Order{}, err
+	}
+	if _, ok := failedCondition(canceled, 0); ok {
+		return Order{}, ErrOrderChanged
+	}
+	if reason, ok := failedCondition(canceled, 1); ok {
+		return Order{}, conflictFromItem(moved, reason.Item)
+	}
+	return Order{}, err
+}
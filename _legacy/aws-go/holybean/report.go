@@ -0,0 +1,151 @@
+package holybean
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Change는 두 기간의 한 값과 그 차이입니다.
+// PercentChange는 이전 값 대비 증감률(%)이며 소수 첫째 자리까지 반올림합니다.
+// 이전 값이 0이면 비율을 정할 수 없으므로 nil입니다.
+type Change[T ~int | ~int64] struct {
+	Current       T        `json:"current"`
+	Previous      T        `json:"previous"`
+	Delta         T        `json:"delta"`
+	PercentChange *float64 `json:"percentChange"`
+}
+
+// NewChange는 current와 previous의 차이와 증감률을 계산합니다.
+func NewChange[T ~int | ~int64](current, previous T) Change[T] {
+	change := Change[T]{Current: current, Previous: previous, Delta: current - previous}
+	if previous != 0 {
+		percent := math.Round(float64(current-previous)/math.Abs(float64(previous))*1000) / 10
+		change.PercentChange = &percent
+	}
+	return change
+}
+
+// MenuSalesChange는 메뉴 하나의 판매 수량·매출 비교입니다.
+type MenuSalesChange struct {
+	Quantity Change[int] `json:"quantity"`
+	Sales    Change[Won] `json:"sales"`
+}
+
+// RollupComparison은 두 기간 집계의 메뉴별·결제 수단별 비교입니다.
+// 한쪽 기간에만 있는 메뉴나 결제 수단도 다른 쪽을 0으로 두고 포함합니다.
+type RollupComparison struct {
+	MenuSales    map[string]MenuSalesChange `json:"menuSales"`
+	PaymentSales map[string]Change[Won]     `json:"paymentSales"`
+	Total        Change[Won]                `json:"total"`
+}
+
+// CompareRollups는 current 기간 집계를 previous 기간 집계와 비교합니다.
+func CompareRollups(current, previous Rollup) RollupComparison {
+	current, previous = current.Compact(), previous.Compact()
+	comparison := RollupComparison{
+		MenuSales:    make(map[string]MenuSalesChange),
+		PaymentSales: make(map[string]Change[Won]),
+		Total:        NewChange(current.Total, previous.Total),
+	}
+	for _, sales := range []map[string]MenuRollup{current.MenuSales, previous.MenuSales} {
+		for name := range sales {
+			now, before := current.MenuSales[name], previous.MenuSales[name]
+			comparison.MenuSales[name] = MenuSalesChange{
+				Quantity: NewChange(now.Quantity, before.Quantity),
+				Sales:    NewChange(now.Sales, before.Sales),
+			}
+		}
+	}
+	for _, sales := range []map[string]Won{current.PaymentSales, previous.PaymentSales} {
+		for method := range sales {
+			comparison.PaymentSales[method] = NewChange(current.PaymentSales[method], previous.PaymentSales[method])
+		}
+	}
+	return comparison
+}
+
+// SumRollups는 일별 집계를 모두 더합니다. 0이 된 항목은 뺍니다.
+func SumRollups(rollups []Rollup) Rollup {
+	var sum Rollup
+	for _, rollup := range rollups {
+		sum.Add(rollup, 1)
+	}
+	return sum.Compact()
+}
+
+// WeekTotal은 한 주(월요일 시작)의 정산 완료 매출 합계입니다.
+// 기간 경계에 걸친 주는 기간 안의 날만 더하므로 Start·End가 주의 시작·끝과 다를 수 있습니다.
+type WeekTotal struct {
+	WeekStart string `json:"weekStart"`
+	Start     string `json:"start"`
+	End       string `json:"end"`
+	Total     Won    `json:"total"`
+}
+
+// WeeklyTotals는 [start, end] 기간을 월요일 시작 주 단위로 나눠 주별 합계를 반환합니다.
+// 집계가 없는 주도 0으로 포함하므로 기간의 모든 주가 순서대로 들어 있습니다.
+func WeeklyTotals(start, end time.Time, rollups []Rollup) []WeekTotal {
+	totals := make(map[string]Won, len(rollups))
+	for _, rollup := range rollups {
+		totals[rollup.Date] += rollup.Total
+	}
+
+	var weeks []WeekTotal
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		date := day.Format(DateLayout)
+		if len(weeks) == 0 || day.Weekday() == time.Monday {
+			weekStart := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+			weeks = append(weeks, WeekTotal{WeekStart: weekStart.Format(DateLayout), Start: date})
+		}
+		week := &weeks[len(weeks)-1]
+		week.End = date
+		week.Total += totals[date]
+	}
+	return weeks
+}
+
+// TimeBucket은 하루 중 한 시간 구간(Start 이상 End 미만, HH:MM)의 주문 수와 매출입니다.
+type TimeBucket struct {
+	Start   string `json:"start,omitempty"`
+	End     string `json:"end,omitempty"`
+	Orders  int    `json:"orders"`
+	Revenue Won    `json:"revenue"`
+}
+
+// TimeOfDay는 주문을 받은 시각(createdAt)별 집계입니다. 여러 날이면 같은 시각 구간끼리 더합니다.
+// Untimed는 createdAt이 없는 예전 주문이라 구간에 넣지 못한 주문입니다.
+type TimeOfDay struct {
+	IntervalMinutes int          `json:"intervalMinutes"`
+	Buckets         []TimeBucket `json:"buckets"`
+	Untimed         TimeBucket   `json:"untimed"`
+}
+
+// TimeOfDayBuckets는 orders를 Asia/Seoul 기준 하루 24시간을 intervalMinutes 간격으로 나눈 구간에 모읍니다.
+// 붐비는 시간을 보려는 것이므로 외상 주문도 주문을 받은 시각에 포함합니다. 취소된 주문은 빠집니다.
+// intervalMinutes는 1440(하루)을 나누어떨어지게 하는 양수여야 합니다.
+func TimeOfDayBuckets(orders []Order, intervalMinutes int) (TimeOfDay, error) {
+	const minutesPerDay = 24 * 60
+	if intervalMinutes <= 0 || minutesPerDay%intervalMinutes != 0 {
+		return TimeOfDay{}, fmt.Errorf("interval %d does not divide a day", intervalMinutes)
+	}
+	report := TimeOfDay{
+		IntervalMinutes: intervalMinutes,
+		Buckets:         make([]TimeBucket, minutesPerDay/intervalMinutes),
+	}
+	clock := func(minutes int) string { return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60) }
+	for i := range report.Buckets {
+		report.Buckets[i].Start = clock(i * intervalMinutes)
+		report.Buckets[i].End = clock((i + 1) * intervalMinutes)
+	}
+
+	for _, order := range ActiveOrders(orders) {
+		bucket := &report.Untimed
+		if createdAt, err := ParseCreatedAt(order.CreatedAt); err == nil {
+			bucket = &report.Buckets[(createdAt.Hour()*60+createdAt.Minute())/intervalMinutes]
+		}
+		bucket.Orders++
+		bucket.Revenue += order.TotalAmount
+	}
+	return report, nil
+}
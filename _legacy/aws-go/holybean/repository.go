@@ -0,0 +1,94 @@
+package holybean
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrOrderNotFound는 주어진 키의 주문이 없을 때 반환됩니다.
+var ErrOrderNotFound = errors.New("order not found")
+
+// MaxRangeDays는 ListRange가 한 번에 읽는 기간의 최대 날짜 수(양 끝 포함)입니다.
+// ListRange는 날짜마다 Query하므로 기간이 길면 테이블 전체를 Scan하는 것보다 비쌉니다.
+const MaxRangeDays = 731
+
+// ErrRangeTooLong은 ListRange 기간이 MaxRangeDays일보다 길 때 반환됩니다.
+var ErrRangeTooLong = errors.New("date range too long")
+
+// OrderConflictError는 같은 (orderDate, orderNum) 키의 주문이 이미 있어 저장하지 못했을 때 반환됩니다.
+type OrderConflictError struct {
+	Existing Order
+}
+
+func (e *OrderConflictError) Error() string {
+	return fmt.Sprintf("order %s #%d already exists", e.Existing.OrderDate, e.Existing.OrderNum)
+}
+
+// IdempotentReplayError는 이미 처리된 멱등성 키로 주문을 다시 저장하려 할 때 반환됩니다.
+// Original은 그 키로 처음 저장된 주문입니다.
+type IdempotentReplayError struct {
+	Original Order
+}
+
+func (e *IdempotentReplayError) Error() string {
+	return fmt.Sprintf("idempotency key already used for order %s #%d", e.Original.OrderDate, e.Original.OrderNum)
+}
+
+// OrderID는 주문의 기본 키입니다.
+type OrderID struct {
+	OrderDate string `json:"orderDate"`
+	OrderNum  int    `json:"orderNum"`
+}
+
+// OrderRepository는 주문 저장소입니다. 핸들러는 DynamoDB를 직접 호출하지 않고
+// 이 인터페이스만 사용하므로, 메모리 구현으로 AWS 없이 실행하거나 테스트할 수 있습니다.
+type OrderRepository interface {
+	// Put은 새 주문을 저장합니다. 같은 키의 주문이 이미 있으면 덮어쓰지 않고 *OrderConflictError를,
+	// order.IdempotencyKey가 이미 쓰인 키이면 *IdempotentReplayError를 반환합니다.
+	// 멱등성 키 기록과 주문 저장은 함께 성공하거나 함께 실패합니다.
+	Put(ctx context.Context, order Order) error
+	// Get은 주문 한 건을 조회합니다. 없으면 ErrOrderNotFound를 반환합니다.
+	Get(ctx context.Context, orderDate string, orderNum int) (Order, error)
+	// ListByDate는 하루치 주문을 orderNum 오름차순으로 반환합니다.
+	ListByDate(ctx context.Context, orderDate string) ([]Order, error)
+	// Void는 주문을 지우지 않고 취소 기록을 붙여(Order.Void) 취소된 주문을 반환합니다. 주문이 더했던 집계는 함께 빠집니다.
+	// 없으면 ErrOrderNotFound를, 이미 취소된 주문이면 ErrOrderVoided를 반환합니다.
+	Void(ctx context.Context, orderDate string, orderNum int, void OrderVoid) (Order, error)
+	// Amend는 주문 내용과 creditStatus를 고치고(Order.Amend) 고친 주문을 반환합니다. 고치기 전 내용은 주문의 Revisions에 남고,
+	// creditStatus가 바뀌면 정산·정산 취소와 같은 입금과 이력이 외상 장부에 붙습니다. 집계는 바뀐 만큼 함께 고쳐지므로
+	// 외상분과 메뉴 판매가 orderDate와 정산한 날 사이를 옮겨 갑니다. 없으면 ErrOrderNotFound를,
+	// 그 사이 다른 수정이 있었으면 *VersionConflictError를, 고칠 수 없는 상태이면 ErrOrderVoided, ErrCreditRecorded,
+	// ErrNotCredit 중 하나를 반환합니다.
+	Amend(ctx context.Context, orderDate string, orderNum int, amendment OrderAmendment) (Order, error)
+	// Restore는 취소된 주문을 되살리고(Order.Restore) 되살린 주문을 반환합니다. 주문의 집계도 다시 더해집니다.
+	// 없으면 ErrOrderNotFound를, 취소되지 않은 주문이면 ErrNotVoided를 반환합니다.
+	Restore(ctx context.Context, orderDate string, orderNum int) (Order, error)
+	// PayCredit은 외상 장부에 입금 한 건을 기록하고(Order.ApplyPayment) 바뀐 주문을 반환합니다.
+	// 남은 금액이 0이 되면 creditStatus가 CreditSettled로 바뀝니다. 받은 돈은 payment.Date 집계에 더해집니다.
+	// 없으면 ErrOrderNotFound를, 남은 금액보다 많이 내면 *OverpaymentError를 반환합니다.
+	PayCredit(ctx context.Context, orderDate string, orderNum int, payment CreditPayment) (Order, error)
+	// SettleCredits는 ids 주문들의 남은 외상을 모두 정산합니다. 정산할 주문은 모두 함께 성공하거나 함께 실패합니다.
+	// 없는 주문과 이미 정산된 주문은 건너뛰고 결과에 그렇게 표시합니다. 결과는 ids 순서(중복 제거)입니다.
+	// 주문이 MaxBulkSettle개보다 많으면 ErrTooManyOrders를 반환합니다.
+	SettleCredits(ctx context.Context, ids []OrderID, payment CreditPayment) ([]SettleOutcome, error)
+	// UnsettleCredit은 정산된 외상 주문을 다시 미정산으로 되돌리고(Order.Unsettle) 바뀐 주문을 반환합니다.
+	// 정산하며 더했던 집계는 함께 빠집니다. 없으면 ErrOrderNotFound를, 되돌릴 수 없는 상태이면
+	// ErrNotCredit 또는 ErrNotSettled를 반환합니다.
+	UnsettleCredit(ctx context.Context, orderDate string, orderNum int, event CreditEvent) (Order, error)
+	// ListOpenCredits는 creditStatus가 CreditUnpaid이고 취소되지 않은 주문을 orderDate 오름차순으로 반환합니다.
+	ListOpenCredits(ctx context.Context) ([]Order, error)
+	// ListRange는 orderDate가 [start, end] 범위(양 끝 포함, YYYY-MM-DD)인 주문을
+	// (orderDate, orderNum) 오름차순으로 모두 반환합니다. 기간이 MaxRangeDays일보다 길면 ErrRangeTooLong을 반환합니다.
+	ListRange(ctx context.Context, start, end string) ([]Order, error)
+	// ListRollups는 [start, end] 날짜(양 끝 포함)의 일별 집계를 날짜 오름차순으로 반환합니다.
+	// 집계는 Put·Amend·Void·Restore·PayCredit·UnsettleCredit이 주문과 함께 갱신하며, 집계가 없는 날은 빠집니다.
+	// 집계가 없는 날짜에 처음 쓸 때는 그날 주문으로 먼저 채우므로(DayRollup), 있는 집계에는 그날 주문이 모두 들어 있습니다.
+	ListRollups(ctx context.Context, start, end string) ([]Rollup, error)
+	// NextOrderNum은 orderDate 날짜의 주문 번호 카운터를 원자적으로 1 올리고 올린 값을 반환합니다.
+	// 같은 번호를 두 번 돌려주지 않지만, 받아 간 번호가 저장되지 않으면 번호에 빈칸이 생길 수 있습니다.
+	NextOrderNum(ctx context.Context, orderDate string) (int, error)
+	// RaiseOrderCounter는 카운터가 lastOrderNum보다 작을 때만 한 번의 조건부 쓰기로 lastOrderNum까지 올립니다.
+	// 이미 나간 번호를 다시 주지 않도록 카운터는 내리지 않습니다.
+	RaiseOrderCounter(ctx context.Context, orderDate string, lastOrderNum int) error
+}
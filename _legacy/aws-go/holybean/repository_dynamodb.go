@@ -0,0 +1,589 @@
+package holybean
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// DynamoOrderRepository는 holybean 테이블에 주문을 저장하는 OrderRepository입니다.
+type DynamoOrderRepository struct {
+	client    *dynamodb.Client
+	table     string
+	metaTable string
+}
+
+const (
+	// idempotencyPartition은 MetaTable에서 멱등성 키 기록이 모여 있는 파티션 키(pk)입니다.
+	idempotencyPartition = "idempotency"
+	// orderCounterPartition은 MetaTable에서 날짜별 주문 번호 카운터가 모여 있는 파티션 키(pk)입니다. (sk: orderDate)
+	orderCounterPartition = "orderCounter"
+)
+
+// NewDynamoOrderRepository는 OrdersTable과 MetaTable을 사용하는 저장소를 만듭니다.
+func NewDynamoOrderRepository(client *dynamodb.Client) *DynamoOrderRepository {
+	return &DynamoOrderRepository{client: client, table: OrdersTable, metaTable: MetaTable}
+}
+
+// orderNotExists는 같은 키의 주문이 없을 때만 쓰기를 허용하는 조건식입니다.
+const orderNotExists = "attribute_not_exists(orderNum)"
+
+// maxWriteAttempts는 읽은 뒤 조건부로 쓰는 Amend·Void·Restore·PayCredit·UnsettleCredit·SettleCredits가 그 사이 주문이 바뀌어
+// 조건 검사에 실패했을 때 다시 읽고 시도하는 최대 횟수입니다.
+const maxWriteAttempts = 3
+
+// Put은 주문, 멱등성 키 기록, 주문이 더하는 일별 집계를 한 트랜잭션으로 씁니다.
+func (r *DynamoOrderRepository) Put(ctx context.Context, order Order) error {
+	item, err := attributevalue.MarshalMap(order)
+	if err != nil {
+		return fmt.Errorf("marshal order: %w", err)
+	}
+
+	transactItems := []types.TransactWriteItem{
+		{Put: &types.Put{
+			TableName:                           aws.String(r.table),
+			Item:                                item,
+			ConditionExpression:                 aws.String(orderNotExists),
+			ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+		}},
+	}
+	idempotencyIndex := -1
+	if order.IdempotencyKey != "" {
+		idempotencyIndex = len(transactItems)
+		transactItems = append(transactItems, types.TransactWriteItem{Put: &types.Put{
+			TableName: aws.String(r.metaTable),
+			Item: map[string]types.AttributeValue{
+				"pk":        &types.AttributeValueMemberS{Value: idempotencyPartition},
+				"sk":        &types.AttributeValueMemberS{Value: order.IdempotencyKey},
+				"orderDate": &types.AttributeValueMemberS{Value: order.OrderDate},
+				"orderNum":  &types.AttributeValueMemberN{Value: strconv.Itoa(order.OrderNum)},
+			},
+			ConditionExpression:                 aws.String("attribute_not_exists(sk)"),
+			ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+		}})
+	}
+	err = r.writeWithRollups(ctx, transactItems, RollupDelta(Order{}, order))
+	var canceled *types.TransactionCanceledException
+	if !errors.As(err, &canceled) {
+		return err
+	}
+
+	// 멱등성 키가 이미 있으면 주문 충돌보다 재시도로 먼저 판단합니다.
+	if reason, ok := failedCondition(canceled, idempotencyIndex); ok {
+		var record struct {
+			OrderDate string `dynamodbav:"orderDate"`
+			OrderNum  int    `dynamodbav:"orderNum"`
+		}
+		if err := attributevalue.UnmarshalMap(reason.Item, &record); err != nil {
+			return fmt.Errorf("unmarshal idempotency record: %w", err)
+		}
+		original, err := r.Get(ctx, record.OrderDate, record.OrderNum)
+		if errors.Is(err, ErrOrderNotFound) {
+			original = Order{OrderDate: record.OrderDate, OrderNum: record.OrderNum}
+		} else if err != nil {
+			return err
+		}
+		return &IdempotentReplayError{Original: original}
+	}
+	if reason, ok := failedCondition(canceled, 0); ok {
+		return conflictFromItem(order, reason.Item)
+	}
+	return err
+}
+
+// failedCondition은 트랜잭션의 index번째 항목이 조건 검사에 실패해 취소됐는지 확인합니다.
+func failedCondition(canceled *types.TransactionCanceledException, index int) (types.CancellationReason, bool) {
+	if index < 0 || index >= len(canceled.CancellationReasons) {
+		return types.CancellationReason{}, false
+	}
+	reason := canceled.CancellationReasons[index]
+	return reason, aws.ToString(reason.Code) == "ConditionalCheckFailed"
+}
+
+// creditStatusUnchanged는 읽었을 때와 creditStatus가 같을 때만 쓰기를 허용하는 조건식입니다.
+// creditStatus가 없는 오래된 주문은 정산 완료로 읽히므로 그 경우도 같은 것으로 봅니다.
+func creditStatusUnchanged(status CreditStatus) (string, map[string]types.AttributeValue) {
+	values := map[string]types.AttributeValue{
+		":readStatus": &types.AttributeValueMemberN{Value: strconv.Itoa(int(status))},
+	}
+	if status == CreditSettled {
+		return "attribute_exists(orderNum) AND (creditStatus = :readStatus OR attribute_not_exists(creditStatus))", values
+	}
+	return "creditStatus = :readStatus", values
+}
+
+// conflictFromItem은 조건 검사 실패로 돌려받은 기존 항목으로 *OrderConflictError를 만듭니다.
+func conflictFromItem(order Order, item map[string]types.AttributeValue) error {
+	existing := Order{OrderDate: order.OrderDate, OrderNum: order.OrderNum}
+	if len(item) > 0 {
+		if decoded, err := unmarshalOrder(item); err == nil {
+			existing = decoded
+		}
+	}
+	return &OrderConflictError{Existing: existing}
+}
+
+func (r *DynamoOrderRepository) Get(ctx context.Context, orderDate string, orderNum int) (Order, error) {
+	result, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.table),
+		Key:       OrderKey(orderDate, orderNum),
+	})
+	if err != nil {
+		return Order{}, err
+	}
+	if result.Item == nil {
+		return Order{}, ErrOrderNotFound
+	}
+	return unmarshalOrder(result.Item)
+}
+
+func (r *DynamoOrderRepository) ListByDate(ctx context.Context, orderDate string) ([]Order, error) {
+	return r.query(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(r.table),
+		KeyConditionExpression: aws.String("orderDate = :orderDate"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":orderDate": &types.AttributeValueMemberS{Value: orderDate},
+		},
+	})
+}
+
+// Void는 주문에 취소 기록을 붙이고 주문이 더했던 일별 집계에서 빼는 쓰기를 한 트랜잭션으로 합니다.
+func (r *DynamoOrderRepository) Void(ctx context.Context, orderDate string, orderNum int, void OrderVoid) (Order, error) {
+	return r.change(ctx, orderDate, orderNum, func(order Order) (Order, error) { return order.Void(void) }, r.voidUpdate)
+}
+
+// Amend는 주문 내용 변경, 고치기 전 내용 보관, 집계 갱신을 한 트랜잭션으로 합니다.
+// 읽은 뒤 다른 요청이 주문을 바꿨으면 다시 읽으므로, 그 사이 다른 수정이 있었으면 *VersionConflictError가 됩니다.
+func (r *DynamoOrderRepository) Amend(ctx context.Context, orderDate string, orderNum int, amendment OrderAmendment) (Order, error) {
+	return r.change(ctx, orderDate, orderNum, func(order Order) (Order, error) { return order.Amend(amendment) }, r.amendUpdate)
+}
+
+// Restore는 주문의 취소 기록을 지우고 집계를 다시 더하는 쓰기를 한 트랜잭션으로 합니다.
+func (r *DynamoOrderRepository) Restore(ctx context.Context, orderDate string, orderNum int) (Order, error) {
+	return r.change(ctx, orderDate, orderNum, Order.Restore, r.voidUpdate)
+}
+
+// PayCredit은 입금 내역 추가, creditStatus 변경, 받은 날짜 집계 갱신을 한 트랜잭션으로 합니다.
+// 이미 정산된 주문에 전액 정산을 요청하면 쓰지 않고 그대로 반환하므로 집계가 두 번 더해지지 않습니다.
+func (r *DynamoOrderRepository) PayCredit(ctx context.Context, orderDate string, orderNum int, payment CreditPayment) (Order, error) {
+	return r.change(ctx, orderDate, orderNum, func(order Order) (Order, error) { return order.ApplyPayment(payment) }, r.creditUpdate)
+}
+
+// UnsettleCredit은 정산 취소 입금과 이력 추가, creditStatus 변경, 집계 갱신을 한 트랜잭션으로 합니다.
+func (r *DynamoOrderRepository) UnsettleCredit(ctx context.Context, orderDate string, orderNum int, event CreditEvent) (Order, error) {
+	return r.change(ctx, orderDate, orderNum, func(order Order) (Order, error) { return order.Unsettle(event) }, r.creditUpdate)
+}
+
+// change는 주문을 읽어 fn으로 바꾸고, update가 만든 주문 쓰기와 바뀐 만큼의 집계 갱신을 한 트랜잭션으로 보냅니다.
+// update가 nil을 반환하면 바뀐 것이 없으므로 쓰지 않습니다. 읽은 뒤 다른 요청이 주문을 바꿨으면 다시 읽어 시도합니다.
+func (r *DynamoOrderRepository) change(ctx context.Context, orderDate string, orderNum int, fn func(Order) (Order, error), update func(before, after Order) (*types.Update, error)) (Order, error) {
+	for attempt := 1; ; attempt++ {
+		order, err := r.Get(ctx, orderDate, orderNum)
+		if err != nil {
+			return Order{}, err
+		}
+		changed, err := fn(order)
+		if err != nil {
+			return Order{}, err
+		}
+		item, err := update(order, changed)
+		if err != nil {
+			return Order{}, err
+		}
+		if item == nil {
+			return changed, nil
+		}
+		err = r.writeWithRollups(ctx, []types.TransactWriteItem{{Update: item}}, RollupDelta(order, changed))
+		if err == nil {
+			return changed, nil
+		}
+		var canceled *types.TransactionCanceledException
+		if !errors.As(err, &canceled) || attempt == maxWriteAttempts {
+			return Order{}, err
+		}
+		if _, ok := failedCondition(canceled, 0); !ok {
+			return Order{}, err
+		}
+	}
+}
+
+// orderUnchanged는 읽었을 때와 creditStatus, 외상 장부 길이, 취소 여부, version이 모두 같을 때만 쓰기를 허용하는 조건식입니다.
+// 이 넷이 주문의 집계를 정하므로, 같으면 읽은 주문으로 계산한 집계 변화량이 맞습니다.
+func orderUnchanged(order Order) (string, map[string]types.AttributeValue) {
+	condition, values := creditStatusUnchanged(order.CreditStatus)
+	if len(order.CreditPayments) == 0 {
+		condition += " AND attribute_not_exists(creditPayments)"
+	} else {
+		condition += " AND size(creditPayments) = :paymentCount"
+		values[":paymentCount"] = &types.AttributeValueMemberN{Value: strconv.Itoa(len(order.CreditPayments))}
+	}
+	if order.IsVoided() {
+		condition += " AND attribute_exists(voided)"
+	} else {
+		condition += " AND attribute_not_exists(voided)"
+	}
+	if order.Version == 0 {
+		condition += " AND attribute_not_exists(version)"
+	} else {
+		condition += " AND version = :readVersion"
+		values[":readVersion"] = &types.AttributeValueMemberN{Value: strconv.Itoa(order.Version)}
+	}
+	return condition, values
+}
+
+// amendUpdate는 after의 주문 내용과 version을 쓰고 새로 더해진 수정 전 내용을 Revisions 끝에 붙이는 항목을 만듭니다.
+// 수정으로 creditStatus가 바뀌어 새로 생긴 외상 입금과 이력도 장부 끝에 붙입니다.
+func (r *DynamoOrderRepository) amendUpdate(before, after Order) (*types.Update, error) {
+	condition, values := orderUnchanged(before)
+	fields := map[string]interface{}{
+		":totalAmount":    after.TotalAmount,
+		":paymentMethods": after.PaymentMethods,
+		":orderItems":     after.OrderItems,
+		":revisions":      after.Revisions[len(before.Revisions):],
+	}
+	for placeholder, field := range fields {
+		value, err := attributevalue.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("marshal %s: %w", placeholder[1:], err)
+		}
+		values[placeholder] = value
+	}
+	values[":status"] = &types.AttributeValueMemberN{Value: strconv.Itoa(int(after.CreditStatus))}
+	values[":version"] = &types.AttributeValueMemberN{Value: strconv.Itoa(after.Version)}
+	values[":empty"] = &types.AttributeValueMemberL{Value: []types.AttributeValue{}}
+	expression := "SET totalAmount = :totalAmount, paymentMethods = :paymentMethods, orderItems = :orderItems, " +
+		"creditStatus = :status, version = :version, revisions = list_append(if_not_exists(revisions, :empty), :revisions)"
+	ledger, err := ledgerAppends(before, after, values)
+	if err != nil {
+		return nil, err
+	}
+	expression += ledger
+	// customerName은 비어 있으면 저장하지 않는 필드이므로 지웁니다.
+	if after.CustomerName == "" {
+		expression += " REMOVE customerName"
+	} else {
+		values[":customerName"] = &types.AttributeValueMemberS{Value: after.CustomerName}
+		expression += ", customerName = :customerName"
+	}
+	return &types.Update{
+		TableName:                 aws.String(r.table),
+		Key:                       OrderKey(before.OrderDate, before.OrderNum),
+		UpdateExpression:          aws.String(expression),
+		ConditionExpression:       aws.String(condition),
+		ExpressionAttributeValues: values,
+	}, nil
+}
+
+// voidUpdate는 after의 취소 기록을 쓰거나(취소) 지우는(되살리기) 항목을 만듭니다.
+func (r *DynamoOrderRepository) voidUpdate(before, after Order) (*types.Update, error) {
+	condition, values := orderUnchanged(before)
+	expression := "REMOVE voided"
+	if after.IsVoided() {
+		void, err := attributevalue.Marshal(after.Voided)
+		if err != nil {
+			return nil, fmt.Errorf("marshal void: %w", err)
+		}
+		values[":void"] = void
+		expression = "SET voided = :void"
+	}
+	return &types.Update{
+		TableName:                 aws.String(r.table),
+		Key:                       OrderKey(before.OrderDate, before.OrderNum),
+		UpdateExpression:          aws.String(expression),
+		ConditionExpression:       aws.String(condition),
+		ExpressionAttributeValues: values,
+	}, nil
+}
+
+// creditUpdate는 after에 새로 더해진 입금과 이력을 장부 끝에 붙이고 creditStatus를 바꾸는 항목을 만듭니다.
+// 읽은 뒤 다른 입금이 먼저 기록되었거나 상태가 바뀌었으면 장부 길이나 상태가 달라 조건 검사에 실패합니다.
+// 붙일 것도 바뀐 상태도 없으면 nil을 반환합니다.
+func (r *DynamoOrderRepository) creditUpdate(before, after Order) (*types.Update, error) {
+	payments := after.CreditPayments[len(before.CreditPayments):]
+	history := after.CreditHistory[len(before.CreditHistory):]
+	if len(payments) == 0 && len(history) == 0 && before.CreditStatus == after.CreditStatus {
+		return nil, nil
+	}
+	condition, values := orderUnchanged(before)
+	values[":status"] = &types.AttributeValueMemberN{Value: strconv.Itoa(int(after.CreditStatus))}
+	ledger, err := ledgerAppends(before, after, values)
+	if err != nil {
+		return nil, err
+	}
+	return &types.Update{
+		TableName:                 aws.String(r.table),
+		Key:                       OrderKey(before.OrderDate, before.OrderNum),
+		UpdateExpression:          aws.String("SET creditStatus = :status" + ledger),
+		ConditionExpression:       aws.String(condition),
+		ExpressionAttributeValues: values,
+	}, nil
+}
+
+// ledgerAppends는 after에 새로 더해진 외상 입금과 이력을 장부 끝에 붙이는 SET 절들을 ", "로 시작하는 문자열로 반환하고 값을 values에 넣습니다.
+// 장부와 이력은 바뀌지 않은 앞부분을 다시 쓰지 않고 새 항목만 덧붙입니다. 빈 목록은 만들지 않습니다.
+func ledgerAppends(before, after Order, values map[string]types.AttributeValue) (string, error) {
+	var expression string
+	appendList := func(attribute, placeholder string, added interface{}, count int) error {
+		if count == 0 {
+			return nil
+		}
+		entries, err := attributevalue.Marshal(added)
+		if err != nil {
+			return fmt.Errorf("marshal %s: %w", attribute, err)
+		}
+		values[placeholder] = entries
+		values[":empty"] = &types.AttributeValueMemberL{Value: []types.AttributeValue{}}
+		expression += fmt.Sprintf(", %s = list_append(if_not_exists(%s, :empty), %s)", attribute, attribute, placeholder)
+		return nil
+	}
+	payments := after.CreditPayments[len(before.CreditPayments):]
+	if err := appendList("creditPayments", ":payments", payments, len(payments)); err != nil {
+		return "", err
+	}
+	history := after.CreditHistory[len(before.CreditHistory):]
+	if err := appendList("creditHistory", ":history", history, len(history)); err != nil {
+		return "", err
+	}
+	return expression, nil
+}
+
+// SettleCredits는 주문들을 읽어 남은 외상을 모두 정산하는 쓰기를 한 TransactWriteItems로 보냅니다.
+// 날짜별 집계 변화량은 주문마다가 아니라 날짜마다 합쳐 한 번씩 더합니다(한 트랜잭션에서 같은 항목은 한 번만 쓸 수 있음).
+// 읽은 뒤 어느 주문이든 바뀌었으면 전부 다시 읽어 시도합니다.
+func (r *DynamoOrderRepository) SettleCredits(ctx context.Context, ids []OrderID, payment CreditPayment) ([]SettleOutcome, error) {
+	ids = uniqueOrderIDs(ids)
+	if len(ids) > MaxBulkSettle {
+		return nil, ErrTooManyOrders
+	}
+	for attempt := 1; ; attempt++ {
+		outcomes := make([]SettleOutcome, len(ids))
+		var transactItems []types.TransactWriteItem
+		var deltas []Rollup
+		for i, id := range ids {
+			order, err := r.Get(ctx, id.OrderDate, id.OrderNum)
+			if errors.Is(err, ErrOrderNotFound) {
+				outcomes[i] = SettleOutcome{OrderID: id, Status: SettleNotFound}
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			paid, outcome, err := settleOutcome(order, payment)
+			if err != nil {
+				return nil, err
+			}
+			outcomes[i] = outcome
+			if outcome.Status != SettleSettled {
+				continue
+			}
+			update, err := r.creditUpdate(order, paid)
+			if err != nil {
+				return nil, err
+			}
+			transactItems = append(transactItems, types.TransactWriteItem{Update: update})
+			deltas = append(deltas, RollupDelta(order, paid)...)
+		}
+		if len(transactItems) == 0 {
+			return outcomes, nil
+		}
+		orderItems := len(transactItems)
+		err := r.writeWithRollups(ctx, transactItems, MergeRollups(deltas))
+		if err == nil {
+			return outcomes, nil
+		}
+		// 읽은 뒤 다른 요청이 주문을 바꿨으면 다시 읽어 시도합니다.
+		var canceled *types.TransactionCanceledException
+		if !errors.As(err, &canceled) || attempt == maxWriteAttempts {
+			return nil, err
+		}
+		changed := false
+		for i := 0; i < orderItems; i++ {
+			if _, ok := failedCondition(canceled, i); ok {
+				changed = true
+			}
+		}
+		if !changed {
+			return nil, err
+		}
+	}
+}
+
+func (r *DynamoOrderRepository) ListOpenCredits(ctx context.Context) ([]Order, error) {
+	return r.query(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(r.table),
+		IndexName:              aws.String(CreditStatusIndex),
+		KeyConditionExpression: aws.String("creditStatus = :creditStatus"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":creditStatus": &types.AttributeValueMemberN{Value: "1"},
+		},
+		FilterExpression: aws.String("attribute_not_exists(voided)"),
+		ScanIndexForward: aws.Bool(true), // ascending order by orderDate
+	})
+}
+
+// rangeQueryConcurrency는 ListRange가 동시에 보내는 날짜별 Query의 최대 개수입니다.
+const rangeQueryConcurrency = 8
+
+// ListRange는 orderDate가 파티션 키이므로 테이블 전체를 Scan하지 않고 날짜마다 Query합니다.
+// 읽기 용량과 지연 시간은 테이블 크기가 아니라 요청한 기간에 비례합니다.
+func (r *DynamoOrderRepository) ListRange(ctx context.Context, start, end string) ([]Order, error) {
+	dates, err := datesBetween(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([][]Order, len(dates))
+	sem := make(chan struct{}, rangeQueryConcurrency)
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var firstErr error
+	for i, date := range dates {
+		wg.Add(1)
+		go func(i int, date string) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+			dayOrders, err := r.ListByDate(ctx, date)
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = fmt.Errorf("query %s: %w", date, err)
+					cancel()
+				})
+				return
+			}
+			results[i] = dayOrders
+		}(i, date)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var orders []Order
+	for _, dayOrders := range results {
+		orders = append(orders, dayOrders...)
+	}
+	return orders, nil
+}
+
+// datesBetween은 start부터 end까지(양 끝 포함) 날짜를 YYYY-MM-DD 문자열로 반환합니다.
+// MaxRangeDays일보다 길면 ErrRangeTooLong을 반환합니다.
+func datesBetween(start, end string) ([]string, error) {
+	from, err := time.Parse(DateLayout, start)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start date %q: %w", start, err)
+	}
+	to, err := time.Parse(DateLayout, end)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end date %q: %w", end, err)
+	}
+	if to.Sub(from) >= MaxRangeDays*24*time.Hour {
+		return nil, fmt.Errorf("%s ~ %s: %w", start, end, ErrRangeTooLong)
+	}
+	var dates []string
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		dates = append(dates, day.Format(DateLayout))
+	}
+	return dates, nil
+}
+
+func (r *DynamoOrderRepository) NextOrderNum(ctx context.Context, orderDate string) (int, error) {
+	// ADD는 항목이 없으면 0에서 시작하므로 그날 첫 호출은 1을 받습니다.
+	result, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(r.metaTable),
+		Key: map[string]types.AttributeValue{
+			"pk": &types.AttributeValueMemberS{Value: orderCounterPartition},
+			"sk": &types.AttributeValueMemberS{Value: orderDate},
+		},
+		UpdateExpression: aws.String("ADD lastOrderNum :one"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":one": &types.AttributeValueMemberN{Value: "1"},
+		},
+		ReturnValues: types.ReturnValueUpdatedNew,
+	})
+	if err != nil {
+		return 0, err
+	}
+	var counter struct {
+		LastOrderNum int `dynamodbav:"lastOrderNum"`
+	}
+	if err := attributevalue.UnmarshalMap(result.Attributes, &counter); err != nil {
+		return 0, fmt.Errorf("unmarshal order counter: %w", err)
+	}
+	return counter.LastOrderNum, nil
+}
+
+func (r *DynamoOrderRepository) RaiseOrderCounter(ctx context.Context, orderDate string, lastOrderNum int) error {
+	_, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(r.metaTable),
+		Key: map[string]types.AttributeValue{
+			"pk": &types.AttributeValueMemberS{Value: orderCounterPartition},
+			"sk": &types.AttributeValueMemberS{Value: orderDate},
+		},
+		UpdateExpression:    aws.String("SET lastOrderNum = :n"),
+		ConditionExpression: aws.String("attribute_not_exists(lastOrderNum) OR lastOrderNum < :n"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":n": &types.AttributeValueMemberN{Value: strconv.Itoa(lastOrderNum)},
+		},
+	})
+	var conditionCheckErr *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionCheckErr) {
+		return nil // 이미 같거나 더 큽니다.
+	}
+	return err
+}
+
+// query는 모든 페이지를 따라가며 결과를 Order로 변환합니다.
+func (r *DynamoOrderRepository) query(ctx context.Context, input *dynamodb.QueryInput) ([]Order, error) {
+	paginator := dynamodb.NewQueryPaginator(r.client, input)
+	var orders []Order
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page.Items {
+			order, err := unmarshalOrder(item)
+			if err != nil {
+				return nil, err
+			}
+			orders = append(orders, order)
+		}
+	}
+	return orders, nil
+}
+
+// unmarshalOrder는 주문 항목을 읽습니다. 금액이 정수가 아니거나 타입이 다르면 0으로 두지 않고
+// 어느 주문인지 담은 오류를 반환합니다.
+func unmarshalOrder(item map[string]types.AttributeValue) (Order, error) {
+	var order Order
+	if err := attributevalue.UnmarshalMap(item, &order); err != nil {
+		date, num := "?", "?"
+		if v, ok := item["orderDate"].(*types.AttributeValueMemberS); ok {
+			date = v.Value
+		}
+		if v, ok := item["orderNum"].(*types.AttributeValueMemberN); ok {
+			num = v.Value
+		}
+		return Order{}, fmt.Errorf("unmarshal order %s #%s: %w", date, num, err)
+	}
+	return order, nil
+}
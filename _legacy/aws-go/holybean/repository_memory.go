@@ -0,0 +1,215 @@
+package holybean
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+type orderKey struct {
+	date string
+	num  int
+}
+
+// MemoryOrderRepository는 프로세스 메모리에 주문을 보관하는 OrderRepository입니다.
+// 로컬 개발과 오프라인 실행, 단위 테스트용입니다.
+type MemoryOrderRepository struct {
+	mu          sync.RWMutex
+	orders      map[orderKey]Order
+	idempotency map[string]orderKey
+	counters    map[string]int
+	rollups     map[string]Rollup
+}
+
+// NewMemoryOrderRepository는 비어 있는 메모리 저장소를 만듭니다.
+func NewMemoryOrderRepository() *MemoryOrderRepository {
+	return &MemoryOrderRepository{
+		orders:      make(map[orderKey]Order),
+		idempotency: make(map[string]orderKey),
+		counters:    make(map[string]int),
+		rollups:     make(map[string]Rollup),
+	}
+}
+
+func (r *MemoryOrderRepository) Put(ctx context.Context, order Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := orderKey{order.OrderDate, order.OrderNum}
+	if order.IdempotencyKey != "" {
+		if originalKey, ok := r.idempotency[order.IdempotencyKey]; ok {
+			original, found := r.orders[originalKey]
+			if !found {
+				original = Order{OrderDate: originalKey.date, OrderNum: originalKey.num}
+			}
+			return &IdempotentReplayError{Original: original.clone()}
+		}
+	}
+	if existing, ok := r.orders[key]; ok {
+		return &OrderConflictError{Existing: existing.clone()}
+	}
+	r.orders[key] = order.clone()
+	if order.IdempotencyKey != "" {
+		r.idempotency[order.IdempotencyKey] = key
+	}
+	r.applyRollups(RollupDelta(Order{}, order))
+	return nil
+}
+
+func (r *MemoryOrderRepository) Get(ctx context.Context, orderDate string, orderNum int) (Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	order, ok := r.orders[orderKey{orderDate, orderNum}]
+	if !ok {
+		return Order{}, ErrOrderNotFound
+	}
+	return order.clone(), nil
+}
+
+func (r *MemoryOrderRepository) ListByDate(ctx context.Context, orderDate string) ([]Order, error) {
+	return r.filter(func(o Order) bool { return o.OrderDate == orderDate }), nil
+}
+
+func (r *MemoryOrderRepository) Void(ctx context.Context, orderDate string, orderNum int, void OrderVoid) (Order, error) {
+	return r.change(orderDate, orderNum, func(order Order) (Order, error) { return order.Void(void) })
+}
+
+func (r *MemoryOrderRepository) Amend(ctx context.Context, orderDate string, orderNum int, amendment OrderAmendment) (Order, error) {
+	return r.change(orderDate, orderNum, func(order Order) (Order, error) { return order.Amend(amendment) })
+}
+
+func (r *MemoryOrderRepository) Restore(ctx context.Context, orderDate string, orderNum int) (Order, error) {
+	return r.change(orderDate, orderNum, Order.Restore)
+}
+
+// change는 주문 하나를 fn으로 바꿔 저장하고 바뀐 만큼 집계를 고칩니다.
+func (r *MemoryOrderRepository) change(orderDate string, orderNum int, fn func(Order) (Order, error)) (Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := orderKey{orderDate, orderNum}
+	order, ok := r.orders[key]
+	if !ok {
+		return Order{}, ErrOrderNotFound
+	}
+	changed, err := fn(order)
+	if err != nil {
+		return Order{}, err
+	}
+	r.orders[key] = changed.clone()
+	r.applyRollups(RollupDelta(order, changed))
+	return changed, nil
+}
+
+func (r *MemoryOrderRepository) PayCredit(ctx context.Context, orderDate string, orderNum int, payment CreditPayment) (Order, error) {
+	return r.change(orderDate, orderNum, func(order Order) (Order, error) { return order.ApplyPayment(payment) })
+}
+
+func (r *MemoryOrderRepository) UnsettleCredit(ctx context.Context, orderDate string, orderNum int, event CreditEvent) (Order, error) {
+	return r.change(orderDate, orderNum, func(order Order) (Order, error) { return order.Unsettle(event) })
+}
+
+func (r *MemoryOrderRepository) SettleCredits(ctx context.Context, ids []OrderID, payment CreditPayment) ([]SettleOutcome, error) {
+	ids = uniqueOrderIDs(ids)
+	if len(ids) > MaxBulkSettle {
+		return nil, ErrTooManyOrders
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	outcomes := make([]SettleOutcome, len(ids))
+	settled := make(map[orderKey]Order)
+	var deltas []Rollup
+	for i, id := range ids {
+		order, ok := r.orders[orderKey{id.OrderDate, id.OrderNum}]
+		if !ok {
+			outcomes[i] = SettleOutcome{OrderID: id, Status: SettleNotFound}
+			continue
+		}
+		paid, outcome, err := settleOutcome(order, payment)
+		if err != nil {
+			return nil, err
+		}
+		outcomes[i] = outcome
+		if outcome.Status == SettleSettled {
+			settled[orderKey{id.OrderDate, id.OrderNum}] = paid
+			deltas = append(deltas, RollupDelta(order, paid)...)
+		}
+	}
+	// 모두 계산한 뒤에 반영하므로 중간에 실패하면 아무것도 바뀌지 않습니다.
+	for key, paid := range settled {
+		r.orders[key] = paid.clone()
+	}
+	r.applyRollups(MergeRollups(deltas))
+	return outcomes, nil
+}
+
+func (r *MemoryOrderRepository) ListOpenCredits(ctx context.Context) ([]Order, error) {
+	return r.filter(func(o Order) bool { return o.CreditStatus == CreditUnpaid && !o.IsVoided() }), nil
+}
+
+func (r *MemoryOrderRepository) ListRange(ctx context.Context, start, end string) ([]Order, error) {
+	if _, err := datesBetween(start, end); err != nil {
+		return nil, err
+	}
+	return r.filter(func(o Order) bool { return o.OrderDate >= start && o.OrderDate <= end }), nil
+}
+
+func (r *MemoryOrderRepository) NextOrderNum(ctx context.Context, orderDate string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counters[orderDate]++
+	return r.counters[orderDate], nil
+}
+
+func (r *MemoryOrderRepository) RaiseOrderCounter(ctx context.Context, orderDate string, lastOrderNum int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.counters[orderDate] < lastOrderNum {
+		r.counters[orderDate] = lastOrderNum
+	}
+	return nil
+}
+
+func (r *MemoryOrderRepository) ListRollups(ctx context.Context, start, end string) ([]Rollup, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var rollups []Rollup
+	for date, rollup := range r.rollups {
+		if date >= start && date <= end {
+			copied := NewRollup(date)
+			copied.Add(rollup, 1)
+			rollups = append(rollups, copied)
+		}
+	}
+	sort.Slice(rollups, func(i, j int) bool { return rollups[i].Date < rollups[j].Date })
+	return rollups, nil
+}
+
+// applyRollups는 날짜별 집계 변화량(RollupDelta)을 더합니다. r.mu를 쥔 채로 호출해야 합니다.
+func (r *MemoryOrderRepository) applyRollups(delta []Rollup) {
+	for _, change := range delta {
+		rollup, ok := r.rollups[change.Date]
+		if !ok {
+			rollup = NewRollup(change.Date)
+		}
+		rollup.Add(change, 1)
+		r.rollups[change.Date] = rollup
+	}
+}
+
+// filter는 조건에 맞는 주문을 (orderDate, orderNum) 오름차순으로 반환합니다.
+func (r *MemoryOrderRepository) filter(match func(Order) bool) []Order {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var orders []Order
+	for _, order := range r.orders {
+		if match(order) {
+			orders = append(orders, order.clone())
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		if orders[i].OrderDate != orders[j].OrderDate {
+			return orders[i].OrderDate < orders[j].OrderDate
+		}
+		return orders[i].OrderNum < orders[j].OrderNum
+	})
+	return orders
+}
@@ -0,0 +1,63 @@
+package holybean
+
+// OrderRequest는 앱이 보내는 주문 요청 본문입니다.
+// 포인터(*) 필드는 'null'과 '존재하지 않는 필드'를 구분하기 위한 필수 값입니다.
+// OrderNum만은 생략할 수 있으며, 생략하면 서버가 번호를 정합니다.
+type OrderRequest struct {
+	OrderNum       *int                   `json:"orderNum"`
+	TotalAmount    *Won                   `json:"totalAmount"`
+	PaymentMethods []RequestPaymentMethod `json:"paymentMethods"`
+	OrderItems     []RequestOrderItem     `json:"orderItems"`
+	CreditStatus   *int                   `json:"creditStatus"`
+	CustomerName   string                 `json:"customerName"` // Optional 필드
+}
+
+// RequestPaymentMethod는 요청 본문의 결제 수단입니다. (type → method)
+type RequestPaymentMethod struct {
+	Type   string `json:"type"`
+	Amount Won    `json:"amount"`
+}
+
+// RequestOrderItem은 요청 본문의 주문 항목입니다. (name/count/total/price → itemName/quantity/subtotal/unitPrice)
+type RequestOrderItem struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+	Total Won    `json:"total"`
+	Price Won    `json:"price"`
+}
+
+// HasRequiredFields는 필수 필드가 모두 채워졌는지 확인합니다.
+func (r OrderRequest) HasRequiredFields() bool {
+	return r.TotalAmount != nil && r.PaymentMethods != nil && r.OrderItems != nil && r.CreditStatus != nil
+}
+
+// ToOrder는 요청을 orderDate 날짜의 저장용 Order로 변환합니다.
+// HasRequiredFields가 true일 때만 호출해야 합니다. OrderNum이 없으면 주문의 OrderNum은 0입니다.
+func (r OrderRequest) ToOrder(orderDate string) Order {
+	order := Order{
+		OrderDate:      orderDate,
+		TotalAmount:    *r.TotalAmount,
+		CustomerName:   r.CustomerName,
+		CreditStatus:   CreditStatus(*r.CreditStatus),
+		OrderItems:     make([]OrderItem, len(r.OrderItems)),
+		PaymentMethods: make([]PaymentMethod, len(r.PaymentMethods)),
+	}
+	if r.OrderNum != nil {
+		order.OrderNum = *r.OrderNum
+	}
+	for i, item := range r.OrderItems {
+		order.OrderItems[i] = OrderItem{
+			ItemName:  item.Name,
+			Quantity:  item.Count,
+			Subtotal:  item.Total,
+			UnitPrice: item.Price,
+		}
+	}
+	for i, method := range r.PaymentMethods {
+		order.PaymentMethods[i] = PaymentMethod{
+			Method: method.Type,
+			Amount: method.Amount,
+		}
+	}
+	return order
+}
@@ -0,0 +1,32 @@
+package holybean
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// jsonHeaders는 응답마다 새 헤더 맵을 만들어, 호출한 쪽이 헤더를 더해도 다른 응답에 섞이지 않게 합니다.
+func jsonHeaders() map[string]string {
+	return map[string]string{"Content-Type": "application/json"}
+}
+
+// JSONResponse는 v를 JSON으로 직렬화해 API Gateway 응답을 만듭니다.
+// 직렬화에 실패하면 500 응답을 대신 반환합니다.
+func JSONResponse(statusCode int, v interface{}) (events.APIGatewayProxyResponse, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("Error marshaling response: %v", err)
+		return events.APIGatewayProxyResponse{
+			StatusCode: 500,
+			Headers:    jsonHeaders(),
+			Body:       `{"code": "INTERNAL_ERROR", "message": "응답을 만들지 못했습니다", "messageEn": "Error marshaling response", "details": []}`,
+		}, nil
+	}
+	return events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Headers:    jsonHeaders(),
+		Body:       string(body),
+	}, nil
+}
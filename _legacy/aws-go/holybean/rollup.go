@@ -0,0 +1,95 @@
+package holybean
+
+// MenuRollup은 하루 동안 한 메뉴의 판매 수량과 매출입니다.
+type MenuRollup struct {
+	Quantity int `json:"quantity"`
+	Sales    Won `json:"sales"`
+}
+
+// Rollup은 하루치 매출 집계입니다. Firebase의 reportRollups와 같은 구성입니다.
+// 외상 주문은 주문한 날이 아니라 돈을 받은 날에 잡힙니다(Order.Rollups).
+type Rollup struct {
+	Date         string                `json:"date"`
+	MenuSales    map[string]MenuRollup `json:"menuSales"`
+	PaymentSales map[string]Won        `json:"paymentSales"`
+	Total        Won                   `json:"total"`
+}
+
+// NewRollup은 date 날짜의 빈 집계를 만듭니다.
+func NewRollup(date string) Rollup {
+	return Rollup{Date: date, MenuSales: make(map[string]MenuRollup), PaymentSales: make(map[string]Won)}
+}
+
+// RollupOf는 주문 한 건이 그 날짜 집계에 더하는 양입니다. 정산 여부는 보지 않습니다.
+func RollupOf(order Order) Rollup {
+	rollup := NewRollup(order.OrderDate)
+	for _, item := range order.OrderItems {
+		sale := rollup.MenuSales[item.ItemName]
+		sale.Quantity += item.Quantity
+		sale.Sales += item.Subtotal
+		rollup.MenuSales[item.ItemName] = sale
+	}
+	for _, payment := range order.PaymentMethods {
+		rollup.PaymentSales[payment.Method] += payment.Amount
+		rollup.Total += payment.Amount
+	}
+	return rollup
+}
+
+// DayRollup은 orders가 date 날짜 집계에 더하는 양의 합입니다(Order.Rollups).
+// 그날 주문만 보므로 다른 날 주문의 외상 입금은 들어가지 않습니다.
+func DayRollup(date string, orders []Order) Rollup {
+	rollup := NewRollup(date)
+	for _, order := range orders {
+		for _, other := range order.Rollups() {
+			if other.Date == date {
+				rollup.Add(other, 1)
+			}
+		}
+	}
+	return rollup
+}
+
+// Add는 other에 sign(1 또는 -1)을 곱해 r에 더합니다.
+func (r *Rollup) Add(other Rollup, sign int) {
+	if r.MenuSales == nil {
+		r.MenuSales = make(map[string]MenuRollup)
+	}
+	if r.PaymentSales == nil {
+		r.PaymentSales = make(map[string]Won)
+	}
+	for name, sale := range other.MenuSales {
+		current := r.MenuSales[name]
+		current.Quantity += sign * sale.Quantity
+		current.Sales += Won(sign) * sale.Sales
+		r.MenuSales[name] = current
+	}
+	for method, amount := range other.PaymentSales {
+		r.PaymentSales[method] += Won(sign) * amount
+	}
+	r.Total += Won(sign) * other.Total
+}
+
+// Compact는 0이 된 메뉴와 결제 수단을 뺀 복사본을 반환합니다.
+// 삭제로 0이 된 항목은 주문이 없던 것과 같으므로 비교하거나 보여 주기 전에 정리합니다.
+func (r Rollup) Compact() Rollup {
+	compact := NewRollup(r.Date)
+	compact.Total = r.Total
+	for name, sale := range r.MenuSales {
+		if sale != (MenuRollup{}) {
+			compact.MenuSales[name] = sale
+		}
+	}
+	for method, amount := range r.PaymentSales {
+		if amount != 0 {
+			compact.PaymentSales[method] = amount
+		}
+	}
+	return compact
+}
+
+// IsZero는 집계에 반영된 주문이 없는 것과 같은지 확인합니다.
+func (r Rollup) IsZero() bool {
+	compact := r.Compact()
+	return compact.Total == 0 && len(compact.MenuSales) == 0 && len(compact.PaymentSales) == 0
+}
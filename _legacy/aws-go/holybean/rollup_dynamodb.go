@@ -0,0 +1,215 @@
+package holybean
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// 일별 집계는 MetaTable의 pk=rollupPartition, sk=orderDate 항목 하나에 평평한 숫자 속성으로 저장합니다.
+// ADD는 중첩 맵 안의 없는 경로에 쓸 수 없으므로 메뉴·결제 수단 이름을 속성 이름에 넣습니다.
+const (
+	rollupPartition      = "rollup"
+	rollupTotalAttr      = "total"
+	rollupMenuQtyPrefix  = "menuQty#"
+	rollupMenuSalePrefix = "menuSales#"
+	rollupPaymentPrefix  = "pay#"
+)
+
+// rollupUpdate는 rollup에 sign을 곱해 그 날짜 집계 항목에 ADD하는 트랜잭션 항목을 만듭니다.
+// 항목이 이미 있을 때만 씁니다. 없는 날짜는 rollupSeed로 통째로 씁니다.
+func (r *DynamoOrderRepository) rollupUpdate(rollup Rollup, sign int) *types.Update {
+	names := make(map[string]string)
+	values := make(map[string]types.AttributeValue)
+	var actions []string
+	add := func(attr string, n int64) {
+		i := len(actions)
+		names[fmt.Sprintf("#a%d", i)] = attr
+		values[fmt.Sprintf(":v%d", i)] = &types.AttributeValueMemberN{Value: strconv.FormatInt(int64(sign)*n, 10)}
+		actions = append(actions, fmt.Sprintf("#a%d :v%d", i, i))
+	}
+
+	add(rollupTotalAttr, int64(rollup.Total))
+	for _, name := range sortedKeys(rollup.MenuSales) {
+		add(rollupMenuQtyPrefix+name, int64(rollup.MenuSales[name].Quantity))
+		add(rollupMenuSalePrefix+name, int64(rollup.MenuSales[name].Sales))
+	}
+	for _, method := range sortedKeys(rollup.PaymentSales) {
+		add(rollupPaymentPrefix+method, int64(rollup.PaymentSales[method]))
+	}
+
+	return &types.Update{
+		TableName: aws.String(r.metaTable),
+		Key: map[string]types.AttributeValue{
+			"pk": &types.AttributeValueMemberS{Value: rollupPartition},
+			"sk": &types.AttributeValueMemberS{Value: rollup.Date},
+		},
+		UpdateExpression:          aws.String("ADD " + strings.Join(actions, ", ")),
+		ConditionExpression:       aws.String("attribute_exists(sk)"),
+		ExpressionAttributeNames:  names,
+		ExpressionAttributeValues: values,
+	}
+}
+
+// rollupSeed는 항목이 없는 date 날짜의 집계를 그날 주문으로 계산하고 delta를 더해, 항목이 없을 때만 통째로 쓰는 트랜잭션 항목을 만듭니다.
+// 집계를 도입하기 전 주문은 집계 항목에 없으므로, 변화량만 ADD하면 그날 집계가 음수나 일부만 남습니다.
+// 다른 날 주문의 외상 입금은 그날 주문에 없으므로 빠집니다. 이는 holybean-rebuild가 채웁니다.
+func (r *DynamoOrderRepository) rollupSeed(ctx context.Context, delta Rollup) (*types.Put, error) {
+	orders, err := r.ListByDate(ctx, delta.Date)
+	if err != nil {
+		return nil, fmt.Errorf("seed rollup %s: %w", delta.Date, err)
+	}
+	seed := DayRollup(delta.Date, orders)
+	seed.Add(delta, 1)
+	return &types.Put{
+		TableName:           aws.String(r.metaTable),
+		Item:                rollupItem(seed),
+		ConditionExpression: aws.String("attribute_not_exists(sk)"),
+	}, nil
+}
+
+// rollupItem은 rollup을 집계 항목 하나로 만듭니다. 0인 메뉴와 결제 수단은 쓰지 않습니다.
+func rollupItem(rollup Rollup) map[string]types.AttributeValue {
+	rollup = rollup.Compact()
+	item := map[string]types.AttributeValue{
+		"pk":            &types.AttributeValueMemberS{Value: rollupPartition},
+		"sk":            &types.AttributeValueMemberS{Value: rollup.Date},
+		rollupTotalAttr: &types.AttributeValueMemberN{Value: rollup.Total.String()},
+	}
+	for name, sale := range rollup.MenuSales {
+		item[rollupMenuQtyPrefix+name] = &types.AttributeValueMemberN{Value: strconv.Itoa(sale.Quantity)}
+		item[rollupMenuSalePrefix+name] = &types.AttributeValueMemberN{Value: sale.Sales.String()}
+	}
+	for method, amount := range rollup.PaymentSales {
+		item[rollupPaymentPrefix+method] = &types.AttributeValueMemberN{Value: amount.String()}
+	}
+	return item
+}
+
+// writeWithRollups는 items와 날짜별 집계 변화량(RollupDelta) delta를 한 트랜잭션으로 씁니다.
+// 집계 항목이 있는 날짜는 변화량을 ADD하고, 없는 날짜는 rollupSeed로 그날 주문까지 더해 통째로 씁니다.
+// 그 사이 다른 요청이 같은 날짜 항목을 만들었거나 지웠으면 그 날짜만 바꿔 다시 시도합니다.
+// 새 날짜의 첫 쓰기는 ADD가 한 번 실패한 뒤 통째로 쓰므로 하루에 한 번 트랜잭션이 더 듭니다.
+// items의 조건 검사가 실패하면 다시 시도하지 않고 *types.TransactionCanceledException을 그대로 반환합니다.
+func (r *DynamoOrderRepository) writeWithRollups(ctx context.Context, items []types.TransactWriteItem, delta []Rollup) error {
+	missing := make(map[string]bool)
+	for attempt := 1; ; attempt++ {
+		transactItems := append([]types.TransactWriteItem(nil), items...)
+		for _, rollup := range delta {
+			if !missing[rollup.Date] {
+				transactItems = append(transactItems, types.TransactWriteItem{Update: r.rollupUpdate(rollup, 1)})
+				continue
+			}
+			seed, err := r.rollupSeed(ctx, rollup)
+			if err != nil {
+				return err
+			}
+			transactItems = append(transactItems, types.TransactWriteItem{Put: seed})
+		}
+
+		_, err := r.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: transactItems})
+		if err == nil {
+			return nil
+		}
+		var canceled *types.TransactionCanceledException
+		if !errors.As(err, &canceled) || attempt == maxWriteAttempts {
+			return err
+		}
+		for i := range items {
+			if _, ok := failedCondition(canceled, i); ok {
+				return err
+			}
+		}
+		retry := false
+		for i, rollup := range delta {
+			if _, ok := failedCondition(canceled, len(items)+i); ok {
+				missing[rollup.Date] = !missing[rollup.Date]
+				retry = true
+			}
+		}
+		if !retry {
+			return err
+		}
+	}
+}
+
+func (r *DynamoOrderRepository) ListRollups(ctx context.Context, start, end string) ([]Rollup, error) {
+	paginator := dynamodb.NewQueryPaginator(r.client, &dynamodb.QueryInput{
+		TableName:              aws.String(r.metaTable),
+		KeyConditionExpression: aws.String("pk = :pk AND sk BETWEEN :start AND :end"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":    &types.AttributeValueMemberS{Value: rollupPartition},
+			":start": &types.AttributeValueMemberS{Value: start},
+			":end":   &types.AttributeValueMemberS{Value: end},
+		},
+	})
+	var rollups []Rollup
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page.Items {
+			rollup, err := unmarshalRollup(item)
+			if err != nil {
+				return nil, err
+			}
+			rollups = append(rollups, rollup)
+		}
+	}
+	return rollups, nil
+}
+
+func unmarshalRollup(item map[string]types.AttributeValue) (Rollup, error) {
+	sk, ok := item["sk"].(*types.AttributeValueMemberS)
+	if !ok {
+		return Rollup{}, fmt.Errorf("unmarshal rollup: sk is missing or not a string")
+	}
+	rollup := NewRollup(sk.Value)
+	for attr, value := range item {
+		if attr == "pk" || attr == "sk" {
+			continue
+		}
+		n, ok := value.(*types.AttributeValueMemberN)
+		if !ok {
+			return Rollup{}, fmt.Errorf("unmarshal rollup %s: %s is not a number", rollup.Date, attr)
+		}
+		amount, err := ParseWon(n.Value)
+		if err != nil {
+			return Rollup{}, fmt.Errorf("unmarshal rollup %s: %s: %w", rollup.Date, attr, err)
+		}
+		switch {
+		case attr == rollupTotalAttr:
+			rollup.Total = amount
+		case strings.HasPrefix(attr, rollupMenuQtyPrefix):
+			name := strings.TrimPrefix(attr, rollupMenuQtyPrefix)
+			sale := rollup.MenuSales[name]
+			sale.Quantity = int(amount)
+			rollup.MenuSales[name] = sale
+		case strings.HasPrefix(attr, rollupMenuSalePrefix):
+			name := strings.TrimPrefix(attr, rollupMenuSalePrefix)
+			sale := rollup.MenuSales[name]
+			sale.Sales = amount
+			rollup.MenuSales[name] = sale
+		case strings.HasPrefix(attr, rollupPaymentPrefix):
+			rollup.PaymentSales[strings.TrimPrefix(attr, rollupPaymentPrefix)] = amount
+		}
+	}
+	return rollup, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
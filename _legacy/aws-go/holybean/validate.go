@@ -0,0 +1,99 @@
+package holybean
+
+import "fmt"
+
+// FieldError는 요청의 한 필드에 대한 오류이며, 오류 응답의 details 항목입니다.
+// Field는 요청 JSON 기준 경로(예: "orderItems[0].total") 또는 파라미터 이름입니다.
+type FieldError struct {
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// Validate는 주문 금액이 서로 맞는지 검사하고 어긋난 항목을 모두 반환합니다.
+//   - orderItems는 비어 있으면 안 됩니다.
+//   - totalAmount, price, total은 음수일 수 없고, paymentMethods의 amount는 0보다 커야 합니다.
+//   - count는 음수일 수 없고, count × price = total이어야 합니다.
+//   - total의 합은 totalAmount와 같아야 합니다.
+//   - paymentMethods의 amount 합은 totalAmount와 같아야 합니다.
+//   - creditStatus는 0(정산) 또는 1(외상)이어야 합니다.
+//
+// 곱과 합이 int64 범위를 넘으면 값이 돌아 엉뚱한 total과 맞을 수 있으므로 범위를 넘는 것도 오류로 봅니다.
+// HasRequiredFields가 true일 때만 호출해야 합니다.
+func (r OrderRequest) Validate() []FieldError {
+	var errs []FieldError
+	if *r.TotalAmount < 0 {
+		errs = append(errs, FieldError{"totalAmount", fmt.Sprintf("총액은 음수일 수 없습니다: %d", *r.TotalAmount)})
+	}
+	if len(r.OrderItems) == 0 {
+		errs = append(errs, FieldError{"orderItems", "주문 항목이 하나 이상 있어야 합니다"})
+	}
+
+	var itemsTotal Won
+	itemsOK := true
+	for i, item := range r.OrderItems {
+		valid := true
+		if item.Count < 0 {
+			errs = append(errs, FieldError{fmt.Sprintf("orderItems[%d].count", i), fmt.Sprintf("수량은 음수일 수 없습니다: %d", item.Count)})
+			valid = false
+		}
+		if item.Price < 0 {
+			errs = append(errs, FieldError{fmt.Sprintf("orderItems[%d].price", i), fmt.Sprintf("단가는 음수일 수 없습니다: %d", item.Price)})
+			valid = false
+		}
+		if item.Total < 0 {
+			errs = append(errs, FieldError{fmt.Sprintf("orderItems[%d].total", i), fmt.Sprintf("소계는 음수일 수 없습니다: %d", item.Total)})
+			valid = false
+		}
+		if !valid {
+			itemsOK = false
+			continue
+		}
+		expected, ok := mulWon(item.Count, item.Price)
+		if !ok {
+			errs = append(errs, FieldError{
+				fmt.Sprintf("orderItems[%d].total", i),
+				fmt.Sprintf("수량 × 단가가 너무 큽니다 (%d × %d)", item.Count, item.Price),
+			})
+			itemsOK = false
+			continue
+		}
+		if expected != item.Total {
+			errs = append(errs, FieldError{
+				fmt.Sprintf("orderItems[%d].total", i),
+				fmt.Sprintf("소계가 수량 × 단가와 다릅니다 (%d × %d = %d, 소계 %d)", item.Count, item.Price, expected, item.Total),
+			})
+		}
+		if sum, ok := addWon(itemsTotal, item.Total); ok {
+			itemsTotal = sum
+		} else if itemsOK {
+			errs = append(errs, FieldError{"orderItems", "소계 합이 너무 큽니다"})
+			itemsOK = false
+		}
+	}
+	if len(r.OrderItems) > 0 && itemsOK && itemsTotal != *r.TotalAmount {
+		errs = append(errs, FieldError{"totalAmount", fmt.Sprintf("총액이 소계 합과 다릅니다 (소계 합 %d, 총액 %d)", itemsTotal, *r.TotalAmount)})
+	}
+
+	var paid Won
+	paidOK := true
+	for i, method := range r.PaymentMethods {
+		if method.Amount <= 0 {
+			errs = append(errs, FieldError{fmt.Sprintf("paymentMethods[%d].amount", i), fmt.Sprintf("결제 금액은 0보다 커야 합니다: %d", method.Amount)})
+			paidOK = false
+			continue
+		}
+		if sum, ok := addWon(paid, method.Amount); ok {
+			paid = sum
+		} else if paidOK {
+			errs = append(errs, FieldError{"paymentMethods", "결제 금액 합이 너무 큽니다"})
+			paidOK = false
+		}
+	}
+	if paidOK && paid != *r.TotalAmount {
+		errs = append(errs, FieldError{"paymentMethods", fmt.Sprintf("결제 금액 합이 총액과 다릅니다 (결제 합 %d, 총액 %d)", paid, *r.TotalAmount)})
+	}
+	if status := CreditStatus(*r.CreditStatus); status != CreditSettled && status != CreditUnpaid {
+		errs = append(errs, FieldError{"creditStatus", fmt.Sprintf("creditStatus는 0(정산) 또는 1(외상)이어야 합니다: %d", *r.CreditStatus)})
+	}
+	return errs
+}
@@ -0,0 +1,56 @@
+package holybean
+
+import "errors"
+
+var (
+	// ErrOrderVoided는 취소된 주문을 바꾸거나 다시 취소하려 할 때 반환됩니다.
+	ErrOrderVoided = errors.New("order is voided")
+	// ErrNotVoided는 취소되지 않은 주문을 되살리려 할 때 반환됩니다.
+	ErrNotVoided = errors.New("order is not voided")
+)
+
+// OrderVoid는 주문을 취소(삭제)한 기록입니다. 취소된 주문은 지우지 않고 이 기록을 붙여 남깁니다.
+type OrderVoid struct {
+	// At은 취소한 시각(Asia/Seoul, RFC 3339)입니다.
+	At       string `json:"at" dynamodbav:"at"`
+	Operator string `json:"operator,omitempty" dynamodbav:"operator,omitempty"`
+	Reason   string `json:"reason,omitempty" dynamodbav:"reason,omitempty"`
+}
+
+// IsVoided는 취소된 주문인지 확인합니다.
+func (o Order) IsVoided() bool {
+	return o.Voided != nil
+}
+
+// Void는 void 기록을 붙여 취소한 주문을 반환합니다. 취소된 주문은 집계(Rollups)와 미정산 외상에서 빠집니다.
+// 이미 취소된 주문이면 ErrOrderVoided를 반환합니다.
+func (o Order) Void(void OrderVoid) (Order, error) {
+	if o.IsVoided() {
+		return Order{}, ErrOrderVoided
+	}
+	voided := o.clone()
+	voided.Voided = &void
+	return voided, nil
+}
+
+// Restore는 취소 기록을 떼어 되살린 주문을 반환합니다. 취소 전과 같이 집계와 미정산 외상에 다시 들어갑니다.
+// 취소되지 않은 주문이면 ErrNotVoided를 반환합니다.
+func (o Order) Restore() (Order, error) {
+	if !o.IsVoided() {
+		return Order{}, ErrNotVoided
+	}
+	restored := o.clone()
+	restored.Voided = nil
+	return restored, nil
+}
+
+// ActiveOrders는 취소되지 않은 주문만 순서대로 반환합니다.
+func ActiveOrders(orders []Order) []Order {
+	active := make([]Order, 0, len(orders))
+	for _, order := range orders {
+		if !order.IsVoided() {
+			active = append(active, order)
+		}
+	}
+	return active
+}